@@ -0,0 +1,303 @@
+package sets
+
+import (
+	"cmp"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+)
+
+// Bag is a multiset: like Map it holds each distinct element once, but it also counts how many times each element has
+// been added. Add increments an element's count and Remove decrements it, so an element stays in the bag until it has
+// been removed as many times as it was added. It is not ordered and does not guarantee the order of elements when
+// iterating over them. It is not safe for concurrent use.
+//
+// Bag satisfies Set[M] for the membership view of its contents: Contains reports whether an element's count is
+// non-zero, Cardinality is the number of distinct elements, and Iterator yields each distinct element once. Count
+// and Total expose the counts. The package-level functions see a Bag only through its Set methods: since Remove and
+// Pop take away a single occurrence, the functions that remove elements (e.g. RemoveSeq) decrement counts rather than
+// drop elements outright.
+//
+// Bag's zero value is ready to use.
+type Bag[M comparable] struct {
+	counts map[M]int // element -> occurrences; every stored count is > 0
+	total  int       // sum of counts
+}
+
+var _ Set[int] = new(Bag[int])
+var _ driver.Valuer = new(Bag[int])
+
+// NewBag returns an empty *Bag[M].
+func NewBag[M comparable]() *Bag[M] {
+	return &Bag[M]{counts: make(map[M]int)}
+}
+
+// NewBagFrom returns a new *Bag[M] filled with the values from the sequence. Values that repeat are counted.
+func NewBagFrom[M comparable](seq iter.Seq[M]) *Bag[M] {
+	b := NewBag[M]()
+	for x := range seq {
+		b.Add(x)
+	}
+	return b
+}
+
+// NewBagWith returns a new *Bag[M] with the values provided. Values that repeat are counted.
+func NewBagWith[M comparable](m ...M) *Bag[M] {
+	return NewBagFrom(slices.Values(m))
+}
+
+// Contains returns true if the bag holds at least one occurrence of the element.
+func (b *Bag[M]) Contains(m M) bool {
+	if b == nil {
+		return false
+	}
+	_, ok := b.counts[m]
+	return ok
+}
+
+// Count returns the number of occurrences of the element in the bag, or 0 if it is not present.
+func (b *Bag[M]) Count(m M) int {
+	if b == nil {
+		return 0
+	}
+	return b.counts[m]
+}
+
+// Total returns the number of occurrences of all elements in the bag, i.e. the sum of their counts. Compare
+// Cardinality, which counts each distinct element once.
+func (b *Bag[M]) Total() int {
+	if b == nil {
+		return 0
+	}
+	return b.total
+}
+
+// Clear removes all elements from the bag and returns the number of distinct elements removed.
+func (b *Bag[M]) Clear() int {
+	n := len(b.counts)
+	if b.counts == nil {
+		b.counts = make(map[M]int)
+	} else {
+		clear(b.counts)
+	}
+	b.total = 0
+	return n
+}
+
+// Drain removes all elements from the bag and returns every occurrence, each element repeated as many times as its
+// count, so the counts are not lost. Returns nil if the bag is empty.
+func (b *Bag[M]) Drain() []M {
+	if b.total == 0 {
+		return nil
+	}
+	out := b.elements()
+	b.Clear()
+	return out
+}
+
+// Add an occurrence of the element to the bag, incrementing its count. Returns true if the element was not already
+// present, false if only its count was incremented.
+func (b *Bag[M]) Add(m M) bool {
+	if b.counts == nil {
+		b.counts = make(map[M]int)
+	}
+	b.counts[m]++
+	b.total++
+	return b.counts[m] == 1
+}
+
+// Merge adds other's elements to the bag in place and returns the number that were not already present. When other
+// is also a *Bag[M] the bags are summed, each element's count increasing by its count in other; otherwise one
+// occurrence of each of other's elements is added.
+func (b *Bag[M]) Merge(other Set[M]) int {
+	o, ok := other.(*Bag[M])
+	if !ok || o == nil {
+		return AppendSeq[M](b, orEmpty(other).Iterator)
+	}
+	if b.counts == nil {
+		b.counts = make(map[M]int, len(o.counts))
+	}
+	var n int
+	for m, c := range o.counts {
+		if b.counts[m] == 0 {
+			n++
+		}
+		b.counts[m] += c
+		b.total += c
+	}
+	return n
+}
+
+// RemoveAll removes all of other's elements from the bag in place, whatever their counts, and returns the number of
+// distinct elements removed. Like Difference, it goes by membership alone, even when other is also a *Bag[M].
+func (b *Bag[M]) RemoveAll(other Set[M]) int {
+	if Set[M](b) == other {
+		return b.Clear()
+	}
+	var n int
+	for m := range orEmpty(other).Iterator {
+		if c, ok := b.counts[m]; ok {
+			delete(b.counts, m)
+			b.total -= c
+			n++
+		}
+	}
+	return n
+}
+
+// Keep removes every element that is not in other from the bag in place, with all of its occurrences, and returns the
+// number of distinct elements removed. It goes by membership alone, even when other is also a *Bag[M]; unlike
+// Intersection, which builds a bag holding one occurrence of each common element, it leaves the counts of the elements
+// kept unchanged.
+func (b *Bag[M]) Keep(other Set[M]) int {
+	if Set[M](b) == other {
+		return 0
+	}
+	other = orEmpty(other)
+	var n int
+	for m, c := range b.counts {
+		if !other.Contains(m) {
+			delete(b.counts, m)
+			b.total -= c
+			n++
+		}
+	}
+	return n
+}
+
+// Remove an occurrence of the element from the bag, decrementing its count; the element leaves the bag when its
+// count reaches zero. Returns true if an occurrence was removed, false if the element was not present.
+func (b *Bag[M]) Remove(m M) bool {
+	c, ok := b.counts[m]
+	if !ok {
+		return false
+	}
+	if c == 1 {
+		delete(b.counts, m)
+	} else {
+		b.counts[m] = c - 1
+	}
+	b.total--
+	return true
+}
+
+// Cardinality returns the number of distinct elements in the bag. See Total for the number of occurrences.
+func (b *Bag[M]) Cardinality() int {
+	if b == nil {
+		return 0
+	}
+	return len(b.counts)
+}
+
+// Iterator yields each distinct element in the bag once, regardless of its count.
+func (b *Bag[M]) Iterator(yield func(M) bool) {
+	if b == nil {
+		return
+	}
+	for k := range b.counts {
+		if !yield(k) {
+			return
+		}
+	}
+}
+
+// Clone returns a copy of the bag, counts included. The underlying type is the same as the original bag.
+func (b *Bag[M]) Clone() Set[M] {
+	if b == nil {
+		return NewBag[M]()
+	}
+	c := maps.Clone(b.counts)
+	if c == nil {
+		c = make(map[M]int)
+	}
+	return &Bag[M]{counts: c, total: b.total}
+}
+
+// NewEmpty returns a new empty bag.
+func (b *Bag[M]) NewEmpty() Set[M] {
+	return NewBag[M]()
+}
+
+// Pop removes an occurrence of a random element from the bag and returns the element. If the bag is empty, it
+// returns the zero value of M and false.
+func (b *Bag[M]) Pop() (M, bool) {
+	for k := range b.counts {
+		b.Remove(k)
+		return k, true
+	}
+	var m M
+	return m, false
+}
+
+// MostCommon returns up to n distinct elements of the bag, in descending order of count. The order of elements with
+// equal counts is not defined. It returns nil if n <= 0 or the bag is empty.
+func (b *Bag[M]) MostCommon(n int) []M {
+	if n <= 0 || len(b.counts) == 0 {
+		return nil
+	}
+	all := slices.Collect(maps.Keys(b.counts))
+	slices.SortStableFunc(all, func(x, y M) int {
+		return cmp.Compare(b.counts[y], b.counts[x])
+	})
+	return slices.Clip(all[:min(n, len(all))])
+}
+
+// String representation of the bag. It returns a string of the form Bag[T](map[<element>:<count> ...]).
+func (b *Bag[M]) String() string {
+	var m M
+	return fmt.Sprintf("Bag[%T](%v)", m, b.counts)
+}
+
+// elements returns every occurrence in the bag, each element repeated as many times as its count.
+func (b *Bag[M]) elements() []M {
+	out := make([]M, 0, b.total)
+	for k, c := range b.counts {
+		for range c {
+			out = append(out, k)
+		}
+	}
+	return out
+}
+
+// MarshalJSON implements json.Marshaler. It returns a JSON array in which each element appears as many times as its
+// count, so the counts survive a round trip. If the bag is empty, it returns an empty JSON array.
+func (b *Bag[M]) MarshalJSON() ([]byte, error) {
+	if b.total == 0 {
+		return []byte("[]"), nil
+	}
+	d, err := json.Marshal(b.elements())
+	if err != nil {
+		return d, fmt.Errorf("marshaling bag: %w", err)
+	}
+	return d, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It expects a JSON array of elements and counts each occurrence, so
+// repeated values increase an element's count rather than being dropped. If the JSON is invalid, it returns an error
+// and the bag is left unchanged.
+func (b *Bag[M]) UnmarshalJSON(d []byte) error {
+	var um []M
+	if err := json.Unmarshal(d, &um); err != nil {
+		return fmt.Errorf("unmarshaling bag: %w", err)
+	}
+	b.Clear()
+	for _, m := range um {
+		b.Add(m)
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface. It returns the JSON representation of the bag.
+func (b *Bag[M]) Value() (driver.Value, error) {
+	return b.MarshalJSON()
+}
+
+// Scan implements the sql.Scanner interface. It scans the value from the database into the bag. It expects a JSON
+// array of elements, counting repeats. If the JSON is invalid an error is returned. If the value is nil an empty bag
+// is returned.
+func (b *Bag[M]) Scan(src any) error {
+	return scanValue[M](src, b.Clear, b.UnmarshalJSON)
+}
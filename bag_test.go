@@ -0,0 +1,172 @@
+package sets
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// TestBag_Model checks Bag against a map of counts across random Add/Remove/Pop/Clear sequences.
+func TestBag_Model(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(t *rapid.T) {
+		b := NewBag[int]()
+		model := make(map[int]int)
+		total := 0
+
+		steps := rapid.IntRange(1, 200).Draw(t, "Steps")
+		for range steps {
+			switch rapid.IntRange(0, 3).Draw(t, "Op") {
+			case 0:
+				v := rapid.IntRange(-10, 10).Draw(t, "Add")
+				if b.Add(v) != (model[v] == 0) {
+					t.Fatalf("Add(%d): expected added=%v", v, model[v] == 0)
+				}
+				model[v]++
+				total++
+			case 1:
+				v := rapid.IntRange(-10, 10).Draw(t, "Remove")
+				if b.Remove(v) != (model[v] > 0) {
+					t.Fatalf("Remove(%d): expected removed=%v", v, model[v] > 0)
+				}
+				if model[v] > 0 {
+					total--
+					if model[v]--; model[v] == 0 {
+						delete(model, v)
+					}
+				}
+			case 2:
+				v, ok := b.Pop()
+				if ok != (len(model) > 0) {
+					t.Fatalf("Pop(): expected ok=%v", len(model) > 0)
+				}
+				if ok {
+					if model[v] == 0 {
+						t.Fatalf("Pop(): returned %d, not in the model", v)
+					}
+					total--
+					if model[v]--; model[v] == 0 {
+						delete(model, v)
+					}
+				}
+			case 3:
+				if n := b.Clear(); n != len(model) {
+					t.Fatalf("Clear() = %d, want %d", n, len(model))
+				}
+				clear(model)
+				total = 0
+			}
+
+			if b.Cardinality() != len(model) {
+				t.Fatalf("Cardinality() = %d, want %d", b.Cardinality(), len(model))
+			}
+			if b.Total() != total {
+				t.Fatalf("Total() = %d, want %d", b.Total(), total)
+			}
+			for v := range 21 {
+				v -= 10
+				if b.Count(v) != model[v] {
+					t.Fatalf("Count(%d) = %d, want %d", v, b.Count(v), model[v])
+				}
+				if b.Contains(v) != (model[v] > 0) {
+					t.Fatalf("Contains(%d) = %v, want %v", v, b.Contains(v), model[v] > 0)
+				}
+			}
+		}
+	})
+}
+
+func TestBag_MostCommon(t *testing.T) {
+	t.Parallel()
+
+	b := NewBagWith("a", "b", "b", "c", "c", "c")
+	if got := b.MostCommon(2); !slices.Equal(got, []string{"c", "b"}) {
+		t.Fatalf("MostCommon(2) = %v, want [c b]", got)
+	}
+	if got := b.MostCommon(10); !slices.Equal(got, []string{"c", "b", "a"}) {
+		t.Fatalf("MostCommon(10) = %v, want [c b a]", got)
+	}
+	if got := b.MostCommon(0); got != nil {
+		t.Fatalf("MostCommon(0) = %v, want nil", got)
+	}
+	if got := NewBag[string]().MostCommon(1); got != nil {
+		t.Fatalf("empty MostCommon(1) = %v, want nil", got)
+	}
+}
+
+func TestBag_ZeroValueAndClone(t *testing.T) {
+	t.Parallel()
+
+	var b Bag[int]
+	if b.Cardinality() != 0 || b.Total() != 0 || b.Contains(1) {
+		t.Fatal("zero value Bag is not empty")
+	}
+	b.Add(1)
+	b.Add(1)
+	c := b.Clone().(*Bag[int])
+	c.Remove(1)
+	if b.Count(1) != 2 || c.Count(1) != 1 {
+		t.Fatalf("Clone shares counts: original %d, clone %d", b.Count(1), c.Count(1))
+	}
+	if _, ok := b.NewEmpty().(*Bag[int]); !ok {
+		t.Fatalf("NewEmpty returned %T", b.NewEmpty())
+	}
+	if got, want := b.String(), "Bag[int](map[1:2])"; got != want {
+		t.Fatalf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestBag_JSON(t *testing.T) {
+	t.Parallel()
+
+	b := NewBagWith(1, 2, 2, 3, 3, 3)
+	j, err := json.Marshal(b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var c *Bag[int]
+	if err := json.Unmarshal(j, &c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for v := range 4 {
+		if b.Count(v) != c.Count(v) {
+			t.Fatalf("Count(%d) after round trip = %d, want %d", v, c.Count(v), b.Count(v))
+		}
+	}
+
+	if err := c.UnmarshalJSON([]byte(`["a"]`)); err == nil {
+		t.Fatal("expected error unmarshaling mismatched element type")
+	}
+	if c.Total() != 6 {
+		t.Fatalf("bag changed after failed unmarshal: %v", c)
+	}
+	if err := c.Scan(nil); err != nil || c.Total() != 0 {
+		t.Fatalf("Scan(nil) = %v, Total() = %d", err, c.Total())
+	}
+	if err := c.Scan(`[4,4]`); err != nil || c.Count(4) != 2 {
+		t.Fatalf("Scan = %v, Count(4) = %d", err, c.Count(4))
+	}
+	if v, err := NewBag[int]().Value(); err != nil || string(v.([]byte)) != "[]" {
+		t.Fatalf("empty Value() = %s, %v", v, err)
+	}
+}
+
+func TestBag_Drain(t *testing.T) {
+	t.Parallel()
+
+	b := NewBagWith("a", "b", "b")
+	got := b.Drain()
+	slices.Sort(got)
+	if !slices.Equal(got, []string{"a", "b", "b"}) {
+		t.Fatalf("Drain() = %v, want every occurrence [a b b]", got)
+	}
+	if b.Total() != 0 || b.Cardinality() != 0 {
+		t.Fatalf("bag not empty after Drain: %v", b)
+	}
+	if got := b.Drain(); got != nil {
+		t.Fatalf("Drain() of an empty bag = %v, want nil", got)
+	}
+}
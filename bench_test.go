@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
 	"strconv"
+	"sync/atomic"
 	"testing"
 )
 
@@ -126,6 +128,7 @@ func forEachSizeTwoSet[M cmp.Ordered](
 			for _, e := range all[size/2 : size+size/2] {
 				bSet.Add(e)
 			}
+			b.ReportAllocs() // destination presizing (see capacitySet) shows up as fewer allocs/op
 			for b.Loop() {
 				op(a, bSet)
 			}
@@ -144,6 +147,20 @@ func benchAdd[M cmp.Ordered](b *testing.B, newSet func() Set[M], elems []M) {
 	}
 }
 
+// benchAddExisting re-adds elements that are already present, the path a check-then-insert Add would pay two map
+// lookups for.
+func benchAddExisting[M cmp.Ordered](b *testing.B, newSet func() Set[M], elems []M) {
+	s := newSet()
+	for _, e := range elems {
+		s.Add(e)
+	}
+	for b.Loop() {
+		for _, e := range elems {
+			s.Add(e)
+		}
+	}
+}
+
 func benchContains[M cmp.Ordered](b *testing.B, newSet func() Set[M], elems []M) {
 	s := newSet()
 	for _, e := range elems {
@@ -170,11 +187,26 @@ func benchRemove[M cmp.Ordered](b *testing.B, newSet func() Set[M], elems []M) {
 	}
 }
 
+// benchRemoveMissing measures Remove of elements that are not in the set: the set is emptied by the first pass, so
+// every later pass removes missing elements.
+func benchRemoveMissing[M cmp.Ordered](b *testing.B, newSet func() Set[M], elems []M) {
+	s := newSet()
+	for _, e := range elems {
+		s.Add(e)
+	}
+	for b.Loop() {
+		for _, e := range elems {
+			s.Remove(e)
+		}
+	}
+}
+
 func benchClone[M cmp.Ordered](b *testing.B, newSet func() Set[M], elems []M) {
 	s := newSet()
 	for _, e := range elems {
 		s.Add(e)
 	}
+	b.ReportAllocs()
 	for b.Loop() {
 		s.Clone()
 	}
@@ -225,6 +257,10 @@ func BenchmarkAdd(b *testing.B) {
 	benchEach(b, benchAdd[int], benchAdd[string])
 }
 
+func BenchmarkAddExisting(b *testing.B) {
+	benchEach(b, benchAddExisting[int], benchAddExisting[string])
+}
+
 func BenchmarkContains(b *testing.B) {
 	benchEach(b, benchContains[int], benchContains[string])
 }
@@ -233,6 +269,10 @@ func BenchmarkRemove(b *testing.B) {
 	benchEach(b, benchRemove[int], benchRemove[string])
 }
 
+func BenchmarkRemoveMissing(b *testing.B) {
+	benchEach(b, benchRemoveMissing[int], benchRemoveMissing[string])
+}
+
 // --- Top-level benchmarks: operations returning new sets ---
 
 func BenchmarkClone(b *testing.B) {
@@ -316,3 +356,210 @@ func BenchmarkDifference(b *testing.B) {
 func BenchmarkSymmetricDifference(b *testing.B) {
 	benchEachTwoSet(b, SymmetricDifference[int], SymmetricDifference[string])
 }
+
+// BenchmarkOrderedRemoveSeqHalf removes every other element of a 100k-element Ordered in one RemoveSeq call, which
+// takes Ordered's batched removal path; the PerElement variant is the equivalent loop of single Removes for comparison.
+func BenchmarkOrderedRemoveSeqHalf(b *testing.B) {
+	elems := genInts(100_000)
+	half := make([]int, 0, len(elems)/2)
+	for i := 0; i < len(elems); i += 2 {
+		half = append(half, elems[i])
+	}
+	b.Run("RemoveSeq", func(b *testing.B) {
+		for b.Loop() {
+			b.StopTimer()
+			s := NewOrderedWith(elems...)
+			b.StartTimer()
+			RemoveSeq(s, slices.Values(half))
+		}
+	})
+	b.Run("PerElement", func(b *testing.B) {
+		for b.Loop() {
+			b.StopTimer()
+			s := NewOrderedWith(elems...)
+			b.StartTimer()
+			for _, e := range half {
+				s.Remove(e)
+			}
+		}
+	})
+}
+
+// BenchmarkBitSetUnionDense unions two sets that each cover half of a dense 1M-value range, overlapping by half: two
+// BitSets take the word-wise path, two Maps the generic element-by-element one, and a BitSet with a Map operand falls
+// back to the generic path, for comparison.
+func BenchmarkBitSetUnionDense(b *testing.B) {
+	const n = 1 << 20
+	lo, hi := genInts(n/2), make([]int, 0, n/2)
+	for i := n / 4; i < n/4+n/2; i++ {
+		hi = append(hi, i)
+	}
+	for _, tc := range []struct {
+		name string
+		a, b Set[int]
+	}{
+		{"BitSet", NewBitSetWith(lo...), NewBitSetWith(hi...)},
+		{"Map", NewWith(lo...), NewWith(hi...)},
+		{"BitSetWithMap", NewBitSetWith(lo...), NewWith(hi...)},
+	} {
+		b.Run(tc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				Union(tc.a, tc.b)
+			}
+		})
+	}
+}
+
+// BenchmarkSmallSetCrossover compares SmallSet's linear scans with Map's hashing at the sizes where SmallSet is meant
+// to be used, to locate the crossover point quoted in SmallSet's godoc: Contains looks up every element once, hits and
+// misses alike, and Build adds the elements to a new set.
+func BenchmarkSmallSetCrossover(b *testing.B) {
+	for _, size := range []int{2, 4, 8, 16, 32, 64} {
+		elems := genInts(size)
+		probes := genInts(2 * size)
+		for _, impl := range []struct {
+			name string
+			new  func() Set[int]
+		}{
+			{"SmallSet", func() Set[int] { return NewSmallSet[int]() }},
+			{"Map", func() Set[int] { return New[int]() }},
+		} {
+			s := impl.new()
+			AppendSeq(s, slices.Values(elems))
+			b.Run(fmt.Sprintf("Contains/%s/%d", impl.name, size), func(b *testing.B) {
+				for b.Loop() {
+					for _, p := range probes {
+						s.Contains(p)
+					}
+				}
+			})
+			b.Run(fmt.Sprintf("Build/%s/%d", impl.name, size), func(b *testing.B) {
+				b.ReportAllocs()
+				for b.Loop() {
+					AppendSeq(impl.new(), slices.Values(elems))
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkHybrid compares Hybrid with the SmallSet and Map it switches between, across DefaultHybridThreshold, to
+// justify the threshold: Contains looks up every element once, hits and misses alike, and Add adds the elements to a
+// new set. Below the threshold Hybrid should track SmallSet, above it Map, paying once for the switch.
+func BenchmarkHybrid(b *testing.B) {
+	for _, size := range []int{4, 16, 64, 1024} {
+		elems := genInts(size)
+		probes := genInts(2 * size)
+		for _, impl := range []struct {
+			name string
+			new  func() Set[int]
+		}{
+			{"Hybrid", func() Set[int] { return NewHybrid[int]() }},
+			{"SmallSet", func() Set[int] { return NewSmallSet[int]() }},
+			{"Map", func() Set[int] { return New[int]() }},
+		} {
+			s := impl.new()
+			AppendSeq(s, slices.Values(elems))
+			b.Run(fmt.Sprintf("Contains/%s/%d", impl.name, size), func(b *testing.B) {
+				for b.Loop() {
+					for _, p := range probes {
+						s.Contains(p)
+					}
+				}
+			})
+			b.Run(fmt.Sprintf("Add/%s/%d", impl.name, size), func(b *testing.B) {
+				b.ReportAllocs()
+				for b.Loop() {
+					AppendSeq(impl.new(), slices.Values(elems))
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkLockedIterateConcurrentWrites iterates a 10k-element Locked set, doing a little work per element, while
+// another goroutine writes to it, to weigh Iterator's copy-on-read snapshot against holding the read lock for the whole
+// iteration: the snapshot costs a copy per iteration, but the writer only waits for the copy, not for the per-element
+// work, so it gets more writes in (reported as writes/op).
+func BenchmarkLockedIterateConcurrentWrites(b *testing.B) {
+	const size = 10_000
+	var sink uint64
+	work := func(v int) bool {
+		for i := range 8 {
+			sink += mix64(uint64(v + i))
+		}
+		return true
+	}
+	for _, tc := range []struct {
+		name    string
+		iterate func(s *Locked[int])
+	}{
+		{"Snapshot", func(s *Locked[int]) {
+			s.Iterator(work)
+		}},
+		{"LockHeld", func(s *Locked[int]) {
+			s.RLock()
+			defer s.RUnlock()
+			s.set.Iterator(work)
+		}},
+	} {
+		b.Run(tc.name, func(b *testing.B) {
+			s := NewLockedWith(genInts(size)...)
+			var writes atomic.Int64
+			stop := make(chan struct{})
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				for i := 0; ; i++ {
+					select {
+					case <-stop:
+						return
+					default:
+					}
+					s.Add(size + i%size)
+					s.Remove(size + i%size)
+					writes.Add(1)
+				}
+			}()
+			for b.Loop() {
+				tc.iterate(s)
+			}
+			close(stop)
+			<-done
+			b.ReportMetric(float64(writes.Load())/float64(b.N), "writes/op")
+		})
+	}
+}
+
+// BenchmarkEqualHashed compares every pair of 32 sets of 1k elements that differ only in their last element added, as
+// when matching sets against a cache of known ones: Equal scans each pair until it finds the difference, while
+// EqualHashed compares hashes computed once up front (outside the timed loop, as a cache would hold them).
+func BenchmarkEqualHashed(b *testing.B) {
+	const n, size = 32, 1000
+	sets := make([]Set[int], n)
+	hashes := make([]uint64, n)
+	for i := range sets {
+		s := NewOrderedWith(genInts(size - 1)...)
+		s.Add(size + i)
+		sets[i], hashes[i] = s, Hash[int](s)
+	}
+	b.Run("Equal", func(b *testing.B) {
+		for b.Loop() {
+			for i := range sets {
+				for j := range sets {
+					Equal(sets[i], sets[j])
+				}
+			}
+		}
+	})
+	b.Run("EqualHashed", func(b *testing.B) {
+		for b.Loop() {
+			for i := range sets {
+				for j := range sets {
+					EqualHashed(sets[i], hashes[i], sets[j], hashes[j])
+				}
+			}
+		}
+	})
+}
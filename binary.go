@@ -0,0 +1,174 @@
+package sets
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// binaryVersion is the format version written as the first byte by MarshalBinary. Any change to the layout that
+// follows it must use a new version, so that UnmarshalBinary rejects data it would otherwise misread.
+const binaryVersion = 1
+
+// errBinaryTruncated is returned by UnmarshalBinary when the data ends part way through the set.
+var errBinaryTruncated = errors.New("data is truncated")
+
+// MarshalBinary encodes the set in a compact binary format for persistence. The first byte is a format version, so that
+// a future change to the layout is detected by UnmarshalBinary instead of being misread. It is followed by the number
+// of elements as a varint and then the elements themselves, in the set's iteration order, so ordered sets keep their
+// order:
+//
+//   - signed integers are zig-zag varints and unsigned integers are varints
+//   - float32 and float64 are 4 and 8 bytes, little-endian IEEE 754
+//   - bools are one byte
+//   - strings are a varint length followed by their bytes
+//
+// Elements of any other kind, e.g. structs or pointers, cannot be encoded and MarshalBinary returns an error. A
+// SyncMap's elements are collected in a single pass with Snapshot. A nil set is encoded as an empty one.
+func MarshalBinary[K comparable](s Set[K]) ([]byte, error) {
+	enc, _, err := binaryCodec[K]()
+	if err != nil {
+		return nil, fmt.Errorf("marshaling binary set: %w", err)
+	}
+	var elems []K
+	if sm, ok := s.(*SyncMap[K]); ok {
+		elems = sm.Snapshot()
+	} else {
+		elems = Elements(orEmpty(s))
+	}
+	d := []byte{binaryVersion}
+	d = binary.AppendUvarint(d, uint64(len(elems)))
+	for _, k := range elems {
+		d = enc(d, reflect.ValueOf(k))
+	}
+	return d, nil
+}
+
+// UnmarshalBinary decodes data written by MarshalBinary into the set, replacing its elements; elements are added in
+// the encoded order. It returns an error if the format version is unknown, the data is truncated or has trailing
+// bytes, or K is not a kind MarshalBinary can encode. The set is left unchanged on error.
+func UnmarshalBinary[K comparable](data []byte, s Set[K]) error {
+	_, dec, err := binaryCodec[K]()
+	if err != nil {
+		return fmt.Errorf("unmarshaling binary set: %w", err)
+	}
+	if len(data) == 0 {
+		return fmt.Errorf("unmarshaling binary set: %w", errBinaryTruncated)
+	}
+	if v := data[0]; v != binaryVersion {
+		return fmt.Errorf("unmarshaling binary set: unknown format version %d", v)
+	}
+	data = data[1:]
+	n, w := binary.Uvarint(data)
+	if w <= 0 {
+		return fmt.Errorf("unmarshaling binary set: reading element count: %w", errBinaryTruncated)
+	}
+	data = data[w:]
+	if n > uint64(len(data)) { // every element takes at least one byte
+		return fmt.Errorf("unmarshaling binary set: %d elements: %w", n, errBinaryTruncated)
+	}
+
+	elems := make([]K, n)
+	for i := range elems {
+		if data, err = dec(data, reflect.ValueOf(&elems[i]).Elem()); err != nil {
+			return fmt.Errorf("unmarshaling binary set element %d: %w", i, err)
+		}
+	}
+	if len(data) > 0 {
+		return fmt.Errorf("unmarshaling binary set: %d trailing bytes", len(data))
+	}
+	s.Clear()
+	for _, k := range elems {
+		s.Add(k)
+	}
+	return nil
+}
+
+// binaryCodec returns the functions that append an element of type K to the binary encoding and read one back from
+// it, or an error if K's kind has no binary encoding.
+func binaryCodec[K comparable]() (func([]byte, reflect.Value) []byte, func([]byte, reflect.Value) ([]byte, error), error) {
+	var k K
+	switch kind := reflect.TypeOf(&k).Elem().Kind(); kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(d []byte, v reflect.Value) []byte {
+				return binary.AppendVarint(d, v.Int())
+			}, func(d []byte, v reflect.Value) ([]byte, error) {
+				i, w := binary.Varint(d)
+				if w <= 0 {
+					return d, errBinaryTruncated
+				}
+				if v.OverflowInt(i) {
+					return d, fmt.Errorf("%d overflows %s", i, v.Type())
+				}
+				v.SetInt(i)
+				return d[w:], nil
+			}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return func(d []byte, v reflect.Value) []byte {
+				return binary.AppendUvarint(d, v.Uint())
+			}, func(d []byte, v reflect.Value) ([]byte, error) {
+				u, w := binary.Uvarint(d)
+				if w <= 0 {
+					return d, errBinaryTruncated
+				}
+				if v.OverflowUint(u) {
+					return d, fmt.Errorf("%d overflows %s", u, v.Type())
+				}
+				v.SetUint(u)
+				return d[w:], nil
+			}, nil
+	case reflect.Float32:
+		return func(d []byte, v reflect.Value) []byte {
+				return binary.LittleEndian.AppendUint32(d, math.Float32bits(float32(v.Float())))
+			}, func(d []byte, v reflect.Value) ([]byte, error) {
+				if len(d) < 4 {
+					return d, errBinaryTruncated
+				}
+				v.SetFloat(float64(math.Float32frombits(binary.LittleEndian.Uint32(d))))
+				return d[4:], nil
+			}, nil
+	case reflect.Float64:
+		return func(d []byte, v reflect.Value) []byte {
+				return binary.LittleEndian.AppendUint64(d, math.Float64bits(v.Float()))
+			}, func(d []byte, v reflect.Value) ([]byte, error) {
+				if len(d) < 8 {
+					return d, errBinaryTruncated
+				}
+				v.SetFloat(math.Float64frombits(binary.LittleEndian.Uint64(d)))
+				return d[8:], nil
+			}, nil
+	case reflect.Bool:
+		return func(d []byte, v reflect.Value) []byte {
+				if v.Bool() {
+					return append(d, 1)
+				}
+				return append(d, 0)
+			}, func(d []byte, v reflect.Value) ([]byte, error) {
+				if len(d) < 1 {
+					return d, errBinaryTruncated
+				}
+				if d[0] > 1 {
+					return d, fmt.Errorf("invalid bool byte %d", d[0])
+				}
+				v.SetBool(d[0] == 1)
+				return d[1:], nil
+			}, nil
+	case reflect.String:
+		return func(d []byte, v reflect.Value) []byte {
+				d = binary.AppendUvarint(d, uint64(v.Len()))
+				return append(d, v.String()...)
+			}, func(d []byte, v reflect.Value) ([]byte, error) {
+				n, w := binary.Uvarint(d)
+				if w <= 0 || n > uint64(len(d)-w) {
+					return d, errBinaryTruncated
+				}
+				d = d[w:]
+				v.SetString(string(d[:n]))
+				return d[n:], nil
+			}, nil
+	default:
+		return nil, nil, fmt.Errorf("elements of type %T (kind %s) have no binary encoding", k, kind)
+	}
+}
@@ -0,0 +1,174 @@
+package sets
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		d, err := MarshalBinary[int](New[int]())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []byte{binaryVersion, 0}; !slices.Equal(d, want) {
+			t.Fatalf("MarshalBinary(empty) = %v, want %v", d, want)
+		}
+		s := NewWith(1, 2)
+		if err := UnmarshalBinary[int](d, s); err != nil {
+			t.Fatal(err)
+		}
+		if s.Cardinality() != 0 {
+			t.Fatalf("UnmarshalBinary(empty) left %v", s)
+		}
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		d, err := MarshalBinary[string](nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []byte{binaryVersion, 0}; !slices.Equal(d, want) {
+			t.Fatalf("MarshalBinary(nil) = %v, want %v", d, want)
+		}
+	})
+
+	t.Run("large", func(t *testing.T) {
+		in := NewOrdered[int]()
+		for i := range 100_000 {
+			in.Add((i*7919)%100_000 - 50_000)
+		}
+		d, err := MarshalBinary[int](in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out := NewOrdered[int]()
+		if err := UnmarshalBinary[int](d, out); err != nil {
+			t.Fatal(err)
+		}
+		if !EqualOrdered[int](in, out) {
+			t.Fatal("large Ordered set did not round trip in order")
+		}
+	})
+
+	t.Run("ints", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			in := NewOrderedWith(rapid.SliceOf(rapid.Int64()).Draw(t, "elements")...)
+			d, err := MarshalBinary[int64](in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			out := NewOrdered[int64]()
+			if err := UnmarshalBinary[int64](d, out); err != nil {
+				t.Fatal(err)
+			}
+			if !EqualOrdered[int64](in, out) {
+				t.Fatalf("round trip of %v gave %v", in, out)
+			}
+		})
+	})
+
+	t.Run("strings", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			in := NewWith(rapid.SliceOf(rapid.String()).Draw(t, "elements")...)
+			d, err := MarshalBinary[string](in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			out := New[string]()
+			if err := UnmarshalBinary[string](d, out); err != nil {
+				t.Fatal(err)
+			}
+			if !Equal[string](in, out) {
+				t.Fatalf("round trip of %v gave %v", in, out)
+			}
+		})
+	})
+
+	t.Run("other kinds", func(t *testing.T) {
+		roundTrip(t, NewOrderedWith[uint8](0, 255, 7))
+		roundTrip(t, NewOrderedWith(0.5, math.Inf(-1), -2))
+		roundTrip(t, NewOrderedWith[float32](1.25, math.MaxFloat32))
+		roundTrip[bool](t, NewWith(true, false))
+		roundTrip[direction](t, NewWith(north, south)) // a named string type
+		roundTrip[int](t, NewSyncMapWith(3, 1, 2))
+	})
+}
+
+type direction string
+
+const (
+	north direction = "north"
+	south direction = "south"
+)
+
+func roundTrip[K comparable](t *testing.T, in Set[K]) {
+	t.Helper()
+	d, err := MarshalBinary(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := in.NewEmpty()
+	if err := UnmarshalBinary(d, out); err != nil {
+		t.Fatal(err)
+	}
+	if !Equal(in, out) {
+		t.Fatalf("round trip of %v gave %v", in, out)
+	}
+	if _, ok := in.(interface{ At(int) (K, bool) }); ok && !slices.Equal(Elements(in), Elements(out)) {
+		t.Fatalf("round trip of %v gave %v, out of order", in, out)
+	}
+}
+
+func TestUnmarshalBinaryErrors(t *testing.T) {
+	valid, err := MarshalBinary[int](NewOrderedWith(1, 300, -5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, tc := range map[string]struct {
+		data []byte
+		want string
+	}{
+		"no data":         {nil, "truncated"},
+		"unknown version": {append([]byte{binaryVersion + 1}, valid[1:]...), "unknown format version 2"},
+		"no count":        {valid[:1], "truncated"},
+		"short":           {valid[:len(valid)-1], "truncated"},
+		"count too large": {[]byte{binaryVersion, 200, 1, 2}, "truncated"},
+		"trailing bytes":  {append(slices.Clone(valid), 0), "1 trailing bytes"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s := NewWith(42)
+			err := UnmarshalBinary[int](tc.data, s)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("UnmarshalBinary() error = %v, want one containing %q", err, tc.want)
+			}
+			if !Equal[int](s, NewWith(42)) {
+				t.Fatalf("failed UnmarshalBinary changed the set to %v", s)
+			}
+		})
+	}
+
+	t.Run("overflow", func(t *testing.T) {
+		d, err := MarshalBinary[int](NewWith(1000))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := UnmarshalBinary[int8](d, New[int8]()); err == nil || !strings.Contains(err.Error(), "overflows int8") {
+			t.Fatalf("UnmarshalBinary() error = %v, want an overflow", err)
+		}
+	})
+
+	t.Run("unsupported kind", func(t *testing.T) {
+		type point struct{ x, y int }
+		if _, err := MarshalBinary[point](NewWith(point{1, 2})); err == nil {
+			t.Fatal("MarshalBinary() of a struct set succeeded")
+		}
+		if err := UnmarshalBinary[point]([]byte{binaryVersion, 0}, New[point]()); err == nil {
+			t.Fatal("UnmarshalBinary() into a struct set succeeded")
+		}
+	})
+}
@@ -80,7 +80,8 @@ var _ Equaler[int] = new(BitSet[int])
 var _ Disjointer[int] = new(BitSet[int])
 var _ Subsetter[int] = new(BitSet[int])
 
-// NewBitSet returns an empty *BitSet[M].
+// NewBitSet returns an empty *BitSet[M]. It takes no size hint: to preallocate for a known range of elements, call
+// Reserve(lo, hi) on the result.
 func NewBitSet[M Integer]() *BitSet[M] {
 	return &BitSet[M]{}
 }
@@ -171,6 +172,9 @@ func (s *BitSet[M]) trim() {
 
 // Contains returns true if the set contains the element.
 func (s *BitSet[M]) Contains(m M) bool {
+	if s == nil {
+		return false
+	}
 	u := toUniverse(m)
 	w := u >> 6
 	if len(s.words) == 0 || w < s.start || w >= s.start+uint64(len(s.words)) {
@@ -189,6 +193,13 @@ func (s *BitSet[M]) Clear() int {
 	return n
 }
 
+// Drain removes all elements from the set and returns them in ascending order. Returns nil if the set is empty.
+func (s *BitSet[M]) Drain() []M {
+	out := Elements[M](s)
+	s.Clear()
+	return out
+}
+
 // Add an element to the set. Returns true if the element was added, false if it was
 // already present. Adding an element outside the current span grows the backing
 // array to cover it (see the type comment for the memory implications) and panics
@@ -206,6 +217,72 @@ func (s *BitSet[M]) Add(m M) bool {
 	return true
 }
 
+// Merge adds all of other's elements to the set in place and returns the number that were not already present. When
+// other is also a *BitSet[M] the words are ORed in place, 64 elements per operation, after growing the span to cover
+// other's (see the type comment on memory).
+func (s *BitSet[M]) Merge(other Set[M]) int {
+	o, ok := other.(*BitSet[M])
+	if !ok || o == nil {
+		return AppendSeq[M](s, orEmpty(other).Iterator)
+	}
+	if len(o.words) == 0 {
+		return 0
+	}
+	n := s.card
+	s.grow(o.start)
+	s.grow(o.start + uint64(len(o.words)) - 1)
+	for i, w := range o.words {
+		s.words[o.start-s.start+uint64(i)] |= w
+	}
+	s.recount()
+	return s.card - n
+}
+
+// RemoveAll removes all of other's elements from the set in place and returns the number removed. When other is also
+// a *BitSet[M] the words where the two spans overlap are cleared word-wise. Like Remove, it never shrinks the backing
+// array; call Compact to release memory.
+func (s *BitSet[M]) RemoveAll(other Set[M]) int {
+	o, ok := other.(*BitSet[M])
+	if !ok || o == nil {
+		return RemoveSeq[M](s, orEmpty(other).Iterator)
+	}
+	if len(s.words) == 0 || len(o.words) == 0 {
+		return 0
+	}
+	n := s.card
+	lo := max(s.start, o.start)
+	hi := min(s.start+uint64(len(s.words)), o.start+uint64(len(o.words))) // one past the overlap
+	for w := lo; w < hi; w++ {
+		s.words[w-s.start] &^= o.words[w-o.start]
+	}
+	s.recount()
+	return n - s.card
+}
+
+// Keep removes every element that is not in other from the set in place and returns the number removed. When other is
+// also a *BitSet[M] the words are ANDed in place, and those outside other's span cleared. Like Remove, it never shrinks
+// the backing array; call Compact to release memory.
+func (s *BitSet[M]) Keep(other Set[M]) int {
+	o, ok := other.(*BitSet[M])
+	if !ok || o == nil {
+		return keepOnly[M](s, other)
+	}
+	if o == s {
+		return 0
+	}
+	n := s.card
+	for i := range s.words {
+		w := s.start + uint64(i)
+		if w >= o.start && w < o.start+uint64(len(o.words)) {
+			s.words[i] &= o.words[w-o.start]
+		} else {
+			s.words[i] = 0
+		}
+	}
+	s.recount()
+	return n - s.card
+}
+
 // Remove an element from the set. Returns true if the element was removed, false if
 // it was not present. Remove never shrinks the backing array; call Compact to
 // release memory after removing span-extreme elements.
@@ -234,6 +311,9 @@ func (s *BitSet[M]) Cardinality() int {
 
 // Iterator yields all elements in the set in ascending order.
 func (s *BitSet[M]) Iterator(yield func(M) bool) {
+	if s == nil {
+		return
+	}
 	for i, w := range s.words {
 		base := (s.start + uint64(i)) << 6
 		for w != 0 {
@@ -248,6 +328,9 @@ func (s *BitSet[M]) Iterator(yield func(M) bool) {
 
 // Clone returns a copy of the set. The underlying type is the same as the original set.
 func (s *BitSet[M]) Clone() Set[M] {
+	if s == nil {
+		return NewBitSet[M]()
+	}
 	return &BitSet[M]{words: slices.Clone(s.words), start: s.start, card: s.card}
 }
 
@@ -474,7 +557,7 @@ func (s *BitSet[M]) Scan(src any) error {
 // package-level Union function, which uses this automatically and handles the fallback.
 func (s *BitSet[M]) Union(other Set[M]) (Set[M], bool) {
 	o, ok := other.(*BitSet[M])
-	if !ok {
+	if !ok || s == nil || o == nil { // typed-nil operands decline to the generic path
 		return nil, false
 	}
 	return s.union(o), true
@@ -485,7 +568,7 @@ func (s *BitSet[M]) Union(other Set[M]) (Set[M], bool) {
 // function, which uses this automatically and handles the fallback.
 func (s *BitSet[M]) Intersection(other Set[M]) (Set[M], bool) {
 	o, ok := other.(*BitSet[M])
-	if !ok {
+	if !ok || s == nil || o == nil { // typed-nil operands decline to the generic path
 		return nil, false
 	}
 	return s.intersect(o), true
@@ -496,7 +579,7 @@ func (s *BitSet[M]) Intersection(other Set[M]) (Set[M], bool) {
 // function, which uses this automatically and handles the fallback.
 func (s *BitSet[M]) Difference(other Set[M]) (Set[M], bool) {
 	o, ok := other.(*BitSet[M])
-	if !ok {
+	if !ok || s == nil || o == nil { // typed-nil operands decline to the generic path
 		return nil, false
 	}
 	return s.difference(o), true
@@ -507,7 +590,7 @@ func (s *BitSet[M]) Difference(other Set[M]) (Set[M], bool) {
 // package-level SymmetricDifference function, which uses this automatically and handles the fallback.
 func (s *BitSet[M]) SymmetricDifference(other Set[M]) (Set[M], bool) {
 	o, ok := other.(*BitSet[M])
-	if !ok {
+	if !ok || s == nil || o == nil { // typed-nil operands decline to the generic path
 		return nil, false
 	}
 	return s.symmetricDifference(o), true
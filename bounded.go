@@ -0,0 +1,348 @@
+package sets
+
+import (
+	"cmp"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// Bounded is an insertion-ordered set that holds at most a fixed number of elements, giving a de-duplicated sliding
+// window, e.g. of the last N distinct events seen. When adding a new element would exceed the limit, the oldest element
+// (the one at the front of the order) is evicted first. Adding an element that is already present refreshes it: it
+// moves to the back of the order, as if newly added, and so becomes the last to be evicted. It is backed by an Ordered
+// set and shares its complexity; Add, including any eviction, is O(log N) amortized. It is not safe for concurrent use;
+// wrap it with NewLockedOrderedWrapping when concurrency is needed.
+//
+// Bounded's zero value is not usable; create one with NewBounded. A nil *Bounded is treated as an empty set with no
+// limit by every method except those that add elements, which panic.
+type Bounded[M cmp.Ordered] struct {
+	set   *Ordered[M]
+	limit int
+}
+
+var _ OrderedSet[int] = new(Bounded[int])
+var _ driver.Valuer = new(Bounded[int])
+var _ stableSorter[int] = new(Bounded[int])
+var _ Maxer[int] = new(Bounded[int])
+var _ Minner[int] = new(Bounded[int])
+var _ sortTracker = new(Bounded[int])
+
+// NewBounded returns an empty *Bounded[M] that holds at most limit elements. It panics if limit is less than 1.
+func NewBounded[M cmp.Ordered](limit int) *Bounded[M] {
+	if limit < 1 {
+		panic("sets.NewBounded: limit must be > 0")
+	}
+	return &Bounded[M]{set: NewOrdered[M](), limit: limit}
+}
+
+// Limit returns the maximum number of elements the set holds.
+func (s *Bounded[M]) Limit() int {
+	if s == nil {
+		return 0
+	}
+	return s.limit
+}
+
+// Contains returns true if the set contains the element.
+func (s *Bounded[M]) Contains(m M) bool {
+	if s == nil {
+		return false
+	}
+	return s.set.Contains(m)
+}
+
+// Clear the set and returns the number of elements removed.
+func (s *Bounded[M]) Clear() int {
+	if s == nil {
+		return 0
+	}
+	return s.set.Clear()
+}
+
+// Drain removes all elements from the set and returns them in order, oldest first. Returns nil if the set is empty.
+func (s *Bounded[M]) Drain() []M {
+	if s == nil {
+		return nil
+	}
+	return s.set.Drain()
+}
+
+// Add an element to the back of the set, first evicting the oldest element if the set is full. Returns true if the
+// element was added, false if it was already present, in which case it is moved to the back instead. Use AddEvicting to
+// learn which element, if any, was evicted.
+func (s *Bounded[M]) Add(m M) bool {
+	if s.set.MoveToBack(m) {
+		return false
+	}
+	s.evictFor(1)
+	return s.set.Add(m)
+}
+
+// AddEvicting adds an element like Add and returns the element that was evicted to make room for it, if any. The second
+// return value is false, and the first the zero value of M, if nothing was evicted: because the set had room or because
+// the element was already present and was only moved to the back.
+func (s *Bounded[M]) AddEvicting(m M) (M, bool) {
+	var evicted M
+	if s.set.MoveToBack(m) {
+		return evicted, false
+	}
+	evicted, ok := s.evictFor(1)
+	s.set.Add(m)
+	return evicted, ok
+}
+
+// evictFor removes elements from the front of the set until n more fit within the limit and returns the last element
+// removed, if any.
+func (s *Bounded[M]) evictFor(n int) (M, bool) {
+	var evicted M
+	var ok bool
+	for s.set.Cardinality()+n > s.limit {
+		evicted, ok = s.set.At(0)
+		if !ok {
+			break
+		}
+		s.set.Remove(evicted)
+	}
+	return evicted, ok
+}
+
+// Merge adds each of other's elements in other's iteration order, as Add does, and returns the number that were not
+// already present: elements already in the set move to the back, and the oldest elements are evicted as needed, so
+// with a large enough other only its last Limit elements remain. Merging the set into itself changes nothing.
+func (s *Bounded[M]) Merge(other Set[M]) int {
+	if o, ok := other.(*Bounded[M]); ok && o == s {
+		return 0
+	}
+	return AppendSeq[M](s, orEmpty(other).Iterator)
+}
+
+// RemoveAll removes all of other's elements from the set in place and returns the number removed. It is the in-place
+// form of Difference, avoiding the copy Difference makes. The removals are batched; see Ordered.RemoveAll.
+func (s *Bounded[M]) RemoveAll(other Set[M]) int {
+	if s == nil {
+		return 0
+	}
+	if Set[M](s) == other {
+		return s.Clear()
+	}
+	return RemoveSeq[M](s.set, orEmpty(other).Iterator)
+}
+
+// Keep removes every element that is not in other from the set in place and returns the number removed; the remaining
+// elements keep their order. See Ordered.Keep.
+func (s *Bounded[M]) Keep(other Set[M]) int {
+	if s == nil || Set[M](s) == other {
+		return 0
+	}
+	return s.set.Keep(other)
+}
+
+// Remove an element from the set. Returns true if the element was removed, false if it was not present.
+func (s *Bounded[M]) Remove(m M) bool {
+	if s == nil {
+		return false
+	}
+	return s.set.Remove(m)
+}
+
+// Pop removes and returns the oldest element, the one at the front of the order. If the set is empty, it returns the
+// zero value of M and false.
+func (s *Bounded[M]) Pop() (M, bool) {
+	m, ok := s.At(0)
+	if ok {
+		s.set.Remove(m)
+	}
+	return m, ok
+}
+
+// Cardinality returns the number of elements in the set.
+func (s *Bounded[M]) Cardinality() int {
+	if s == nil {
+		return 0
+	}
+	return s.set.Cardinality()
+}
+
+// Iterator yields all elements in the set in order, oldest first.
+func (s *Bounded[M]) Iterator(yield func(M) bool) {
+	if s == nil {
+		return
+	}
+	s.set.Iterator(yield)
+}
+
+// Ordered iteration yields the index and value of each element in the set in order, oldest first.
+func (s *Bounded[M]) Ordered(yield func(int, M) bool) {
+	if s == nil {
+		return
+	}
+	s.set.Ordered(yield)
+}
+
+// Backwards iteration yields the index and value of each element in the set in reverse order, newest first.
+func (s *Bounded[M]) Backwards(yield func(int, M) bool) {
+	if s == nil {
+		return
+	}
+	s.set.Backwards(yield)
+}
+
+// At returns the element at the index; a negative index counts back from the end, so At(-1) is the newest element. If
+// the index is out of bounds, the second return value is false. See Ordered.At.
+func (s *Bounded[M]) At(i int) (M, bool) {
+	if s == nil {
+		var zero M
+		return zero, false
+	}
+	return s.set.At(i)
+}
+
+// Index returns the index of the element in the set, or -1 if not present.
+func (s *Bounded[M]) Index(m M) int {
+	if s == nil {
+		return -1
+	}
+	return s.set.Index(m)
+}
+
+// IndexFunc returns the index of the first element, in order, for which the function returns true, or -1 if there is
+// none. See Ordered.IndexFunc.
+func (s *Bounded[M]) IndexFunc(f func(M) bool) int {
+	if s == nil {
+		return -1
+	}
+	return s.set.IndexFunc(f)
+}
+
+// Max implements Maxer, returning the largest element in O(log N) when the set is known to be sorted. See Ordered.Max.
+func (s *Bounded[M]) Max() (M, bool) {
+	if s == nil {
+		var zero M
+		return zero, false
+	}
+	return s.set.Max()
+}
+
+// Min implements Minner, returning the smallest element in O(log N) when the set is known to be sorted. See
+// Ordered.Min.
+func (s *Bounded[M]) Min() (M, bool) {
+	if s == nil {
+		var zero M
+		return zero, false
+	}
+	return s.set.Min()
+}
+
+// knownSorted reports whether the backing Ordered set is known to be in ascending order.
+//
+//lint:ignore U1000 reached via the sortTracker type assertion in IsSorted
+func (s *Bounded[M]) knownSorted() bool {
+	return s == nil || s.set.knownSorted()
+}
+
+// Sort the set in ascending order. The smallest element then becomes the next to be evicted.
+func (s *Bounded[M]) Sort() {
+	if s == nil {
+		return
+	}
+	s.set.Sort()
+}
+
+// sortStableFunc stably sorts the backing Ordered set by cmp in place.
+//
+//lint:ignore U1000 reached via the stableSorter type assertion in sortStable
+func (s *Bounded[M]) sortStableFunc(cmp func(M, M) int) {
+	if s == nil {
+		return
+	}
+	s.set.sortStableFunc(cmp)
+}
+
+// MoveToFront moves an element already in the set to the front of the order, making it the next to be evicted.
+// Returns false, leaving the set unchanged, if the element is not present. See Ordered.MoveToFront.
+func (s *Bounded[M]) MoveToFront(m M) bool {
+	if s == nil {
+		return false
+	}
+	return s.set.MoveToFront(m)
+}
+
+// MoveToBack moves an element already in the set to the back of the order, making it the last to be evicted, as
+// re-adding it with Add does. Returns false, leaving the set unchanged, if the element is not present.
+func (s *Bounded[M]) MoveToBack(m M) bool {
+	if s == nil {
+		return false
+	}
+	return s.set.MoveToBack(m)
+}
+
+// Clone returns a copy of the set with the same limit. The underlying type is the same as the original set. A nil
+// *Bounded has no limit to copy, so cloning one returns an empty, unbounded *Ordered.
+func (s *Bounded[M]) Clone() Set[M] {
+	if s == nil {
+		return NewOrdered[M]()
+	}
+	return &Bounded[M]{set: s.set.Clone().(*Ordered[M]), limit: s.limit}
+}
+
+// NewEmpty returns a new empty set with the same limit. As with Clone, a nil *Bounded returns an empty, unbounded
+// *Ordered.
+func (s *Bounded[M]) NewEmpty() Set[M] {
+	return s.NewEmptyOrdered()
+}
+
+// NewEmptyOrdered returns a new empty ordered set with the same limit. As with Clone, a nil *Bounded returns an empty,
+// unbounded *Ordered.
+func (s *Bounded[M]) NewEmptyOrdered() OrderedSet[M] {
+	if s == nil {
+		return NewOrdered[M]()
+	}
+	return NewBounded[M](s.limit)
+}
+
+// String returns a string representation of the set. It returns a string of the form BoundedSet[T](<elements>).
+func (s *Bounded[M]) String() string {
+	var m M
+	return fmt.Sprintf("BoundedSet[%T](%v)", m, Elements[M](s))
+}
+
+// Value implements the driver.Valuer interface. It returns the JSON representation of the set.
+func (s *Bounded[M]) Value() (driver.Value, error) {
+	return s.MarshalJSON()
+}
+
+// MarshalJSON implements json.Marshaler. It will marshal the set into a JSON array of the elements in the set, oldest
+// first. If the set is empty an empty JSON array is returned. The limit is not included.
+func (s *Bounded[M]) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("[]"), nil
+	}
+	d, err := s.set.MarshalJSON()
+	if err != nil {
+		return d, fmt.Errorf("marshaling bounded set: %w", err)
+	}
+	return d, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It expects a JSON array of the elements in the set, oldest first, and
+// keeps the set's limit: if the array holds more distinct elements than the limit, only the last ones are kept, as if
+// they had been added in order. If the JSON is invalid, it returns an error and the set is left unchanged.
+func (s *Bounded[M]) UnmarshalJSON(d []byte) error {
+	var um []M
+	if err := json.Unmarshal(d, &um); err != nil {
+		return fmt.Errorf("unmarshaling bounded set: %w", err)
+	}
+	s.set.Clear()
+	for _, m := range um {
+		s.Add(m)
+	}
+	return nil
+}
+
+// Scan implements the sql.Scanner interface. It scans the value from the database into the set. It expects a JSON array
+// of the elements in the set. If the JSON is invalid an error is returned. If the value is nil an empty set is
+// returned.
+func (s *Bounded[M]) Scan(src any) error {
+	return scanValue[M](src, s.Clear, s.UnmarshalJSON)
+}
@@ -0,0 +1,125 @@
+package sets
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+func TestBounded(t *testing.T) {
+	t.Parallel()
+
+	// a limit that is never reached: Bounded must then behave as any other set
+	setStateMachine := &SetStateMachine{
+		set:    NewBounded[int](1 << 20),
+		stateI: make(map[int]int),
+	}
+	rapid.Check(t, func(t *rapid.T) {
+		t.Repeat(rapid.StateMachineActions(setStateMachine))
+	})
+}
+
+// TestBounded_Window checks Add, AddEvicting, and Pop against a slice model of a de-duplicated sliding window that
+// refreshes re-added elements.
+func TestBounded_Window(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(t *rapid.T) {
+		limit := rapid.IntRange(1, 8).Draw(t, "Limit")
+		s := NewBounded[int](limit)
+		var model []int
+
+		steps := rapid.IntRange(1, 100).Draw(t, "Steps")
+		for range steps {
+			switch rapid.IntRange(0, 2).Draw(t, "Op") {
+			case 0, 1:
+				v := rapid.IntRange(0, 12).Draw(t, "Value")
+				var wantEvicted []int
+				if i := slices.Index(model, v); i >= 0 {
+					model = slices.Delete(model, i, i+1)
+				} else if len(model) == limit {
+					wantEvicted, model = model[:1], model[1:]
+				}
+				model = append(model, v)
+
+				var evicted []int
+				if e, ok := s.AddEvicting(v); ok {
+					evicted = append(evicted, e)
+				}
+				if !slices.Equal(evicted, wantEvicted) {
+					t.Fatalf("AddEvicting(%d) evicted %v, want %v", v, evicted, wantEvicted)
+				}
+			case 2:
+				v, ok := s.Pop()
+				if ok != (len(model) > 0) {
+					t.Fatalf("Pop(): ok = %v, want %v", ok, len(model) > 0)
+				}
+				if ok {
+					if v != model[0] {
+						t.Fatalf("Pop() = %d, want oldest %d", v, model[0])
+					}
+					model = model[1:]
+				}
+			}
+			if got := Elements(s); !slices.Equal(got, model) {
+				t.Fatalf("elements = %v, want %v", got, model)
+			}
+		}
+	})
+}
+
+func TestBounded_Add(t *testing.T) {
+	t.Parallel()
+
+	s := NewBounded[string](2)
+	if !s.Add("a") || !s.Add("b") {
+		t.Fatal("Add of new elements returned false")
+	}
+	if s.Add("a") {
+		t.Fatal("Add of a present element returned true")
+	}
+	if !s.Add("c") { // evicts b, as re-adding a refreshed it
+		t.Fatal("Add of a new element to a full set returned false")
+	}
+	if got := Elements(s); !slices.Equal(got, []string{"a", "c"}) {
+		t.Fatalf("elements = %v, want [a c]", got)
+	}
+	if s.Limit() != 2 || s.Clone().(*Bounded[string]).Limit() != 2 || s.NewEmpty().(*Bounded[string]).Limit() != 2 {
+		t.Fatal("Limit not preserved by Clone or NewEmpty")
+	}
+}
+
+func TestBounded_JSON(t *testing.T) {
+	t.Parallel()
+
+	s := NewBounded[int](3)
+	if err := json.Unmarshal([]byte("[1,2,3,1,4,5]"), s); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := Elements(s); !slices.Equal(got, []int{1, 4, 5}) {
+		t.Fatalf("elements = %v, want [1 4 5]", got)
+	}
+	d, err := json.Marshal(s)
+	if err != nil || string(d) != "[1,4,5]" {
+		t.Fatalf("json.Marshal() = %s, %v, want [1,4,5]", d, err)
+	}
+	if err := json.Unmarshal([]byte("{"), s); err == nil {
+		t.Fatal("expected an error for invalid JSON")
+	}
+	if got := Elements(s); !slices.Equal(got, []int{1, 4, 5}) {
+		t.Fatalf("elements after failed unmarshal = %v, want [1 4 5]", got)
+	}
+}
+
+func TestNewBoundedPanics(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("NewBounded(0) did not panic")
+		}
+	}()
+	NewBounded[int](0)
+}
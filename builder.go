@@ -0,0 +1,51 @@
+package sets
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// Builder accumulates elements from any number of sources and then builds a read-only, sorted set from them. Adding
+// to a Builder only appends; the elements are sorted and de-duplicated once, by Build, so building a large lookup
+// table costs O(N log N) overall rather than the O(N) per element of adding to a SortedSet. Add and AddSeq return the
+// Builder, so calls can be chained:
+//
+//	s := sets.NewBuilder[string]().Add("b", "a").AddSeq(maps.Keys(m)).Build()
+//
+// A Builder is not safe for concurrent use, but the set returned by Build is, as it cannot be modified.
+//
+// Builder's zero value is ready to use.
+type Builder[M cmp.Ordered] struct {
+	el []M
+}
+
+// NewBuilder returns an empty *Builder[M].
+func NewBuilder[M cmp.Ordered]() *Builder[M] {
+	return &Builder[M]{}
+}
+
+// Add the elements to the builder. Duplicates are allowed; they are removed by Build.
+func (b *Builder[M]) Add(m ...M) *Builder[M] {
+	b.el = append(b.el, m...)
+	return b
+}
+
+// AddSeq adds the elements of the sequence to the builder. Duplicates are allowed; they are removed by Build.
+func (b *Builder[M]) AddSeq(seq iter.Seq[M]) *Builder[M] {
+	b.el = slices.AppendSeq(b.el, seq)
+	return b
+}
+
+// Build sorts and de-duplicates the added elements and returns them as a *Frozen[M] set, whose mutating methods are
+// disabled. The builder hands its elements over to the set and is left empty, ready to build another set.
+func (b *Builder[M]) Build() OrderedSet[M] {
+	el := b.el
+	b.el = nil
+	slices.Sort(el)
+	el = slices.Clip(slices.Compact(el))
+	if el == nil {
+		el = make([]M, 0)
+	}
+	return &Frozen[M]{s: SortedSet[M]{el: el}}
+}
@@ -0,0 +1,170 @@
+package sets
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// TestBuilder verifies that Build returns the sorted, de-duplicated elements added from any mix of Add and AddSeq
+// calls, and that the builder is left empty afterwards.
+func TestBuilder(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(t *rapid.T) {
+		var b Builder[int]
+		model := NewSortedSet[int]()
+
+		steps := rapid.IntRange(0, 20).Draw(t, "Steps")
+		for range steps {
+			v := rapid.SliceOf(rapid.IntRange(-50, 50)).Draw(t, "Values")
+			AppendSeq(model, slices.Values(v))
+			if rapid.Bool().Draw(t, "Seq") {
+				b.AddSeq(slices.Values(v))
+			} else {
+				b.Add(v...)
+			}
+		}
+
+		s := b.Build()
+		if !EqualOrdered[int](s, model) {
+			t.Fatalf("Build() = %v, want %v", s, model)
+		}
+		if !IsSorted(s) {
+			t.Fatalf("Build() is not sorted: %v", s)
+		}
+		if again := b.Build(); again.Cardinality() != 0 {
+			t.Fatalf("second Build() = %v, want empty", again)
+		}
+	})
+}
+
+func TestFrozen_MutatorsDisabled(t *testing.T) {
+	t.Parallel()
+
+	s := NewBuilder[int]().Add(3, 1, 2).Build()
+	want := []int{1, 2, 3}
+
+	if s.Add(4) {
+		t.Error("Add() = true, want false")
+	}
+	if s.Remove(1) {
+		t.Error("Remove() = true, want false")
+	}
+	if v, ok := s.Pop(); ok {
+		t.Errorf("Pop() = %d, true, want false", v)
+	}
+	if n := s.Clear(); n != 0 {
+		t.Errorf("Clear() = %d, want 0", n)
+	}
+	if got := Drain(s); got != nil {
+		t.Errorf("Drain() = %v, want nil", got)
+	}
+	if n := AppendSeq(s, slices.Values([]int{8, 9})); n != 0 {
+		t.Errorf("AppendSeq() = %d, want 0", n)
+	}
+	if err := json.Unmarshal([]byte("[9]"), s); !errors.Is(err, ErrFrozen) {
+		t.Errorf("json.Unmarshal() error = %v, want ErrFrozen", err)
+	}
+	if err := s.(*Frozen[int]).Scan(nil); !errors.Is(err, ErrFrozen) {
+		t.Errorf("Scan() error = %v, want ErrFrozen", err)
+	}
+	if got := Elements(s); !slices.Equal(got, want) {
+		t.Fatalf("elements after mutation attempts = %v, want %v", got, want)
+	}
+
+	d, err := json.Marshal(s)
+	if err != nil || string(d) != "[1,2,3]" {
+		t.Errorf("json.Marshal() = %s, %v, want [1,2,3]", d, err)
+	}
+
+	c := s.Clone()
+	if !c.Add(4) || s.Contains(4) {
+		t.Error("Clone() should return an independent, mutable set")
+	}
+}
+
+func TestFrozen_Algebra(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(t *rapid.T) {
+		av := rapid.SliceOf(rapid.IntRange(0, 30)).Draw(t, "a")
+		bv := rapid.SliceOf(rapid.IntRange(0, 30)).Draw(t, "b")
+		fa, fb := NewBuilder[int]().Add(av...).Build(), NewBuilder[int]().Add(bv...).Build()
+		ma, mb := NewWith(av...), NewWith(bv...)
+
+		for _, tc := range []struct {
+			name string
+			a, b Set[int]
+		}{
+			{"Frozen/Frozen", fa, fb},
+			{"Frozen/SortedSet", fa, NewSortedSetWith(bv...)},
+			{"SortedSet/Frozen", NewSortedSetWith(av...), fb},
+			{"Frozen/Map", fa, mb},
+		} {
+			checks := []struct {
+				op        string
+				got, want Set[int]
+			}{
+				{"Union", Union(tc.a, tc.b), Union(ma, mb)},
+				{"Intersection", Intersection(tc.a, tc.b), Intersection(ma, mb)},
+				{"Difference", Difference(tc.a, tc.b), Difference(ma, mb)},
+				{"SymmetricDifference", SymmetricDifference(tc.a, tc.b), SymmetricDifference(ma, mb)},
+			}
+			for _, c := range checks {
+				if !Equal(c.got, c.want) {
+					t.Fatalf("%s %s = %v, want %v", tc.name, c.op, c.got, c.want)
+				}
+				if !c.got.Add(-1) {
+					t.Fatalf("%s %s result should be mutable", tc.name, c.op)
+				}
+			}
+			if got, want := Equal(tc.a, tc.b), Equal(ma, mb); got != want {
+				t.Fatalf("%s Equal = %v, want %v", tc.name, got, want)
+			}
+			if got, want := Disjoint(tc.a, tc.b), Disjoint(ma, mb); got != want {
+				t.Fatalf("%s Disjoint = %v, want %v", tc.name, got, want)
+			}
+			if got, want := Subset(tc.a, tc.b), Subset(ma, mb); got != want {
+				t.Fatalf("%s Subset = %v, want %v", tc.name, got, want)
+			}
+		}
+	})
+}
+
+// TestFrozen_ConcurrentReads shares a frozen set between goroutines without locking; run with -race.
+func TestFrozen_ConcurrentReads(t *testing.T) {
+	t.Parallel()
+
+	s := NewBuilder[int]().AddSeq(slices.Values(genInts(1000))).Build()
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			for v := range s.Iterator {
+				if !s.Contains(v) || s.Index(v) < 0 {
+					t.Errorf("element %d not found", v)
+					return
+				}
+			}
+			s.Add(-1)
+			s.Remove(0)
+		})
+	}
+	wg.Wait()
+}
+
+func TestFrozen_ZeroValue(t *testing.T) {
+	t.Parallel()
+
+	var s Frozen[int]
+	if s.Cardinality() != 0 || s.Contains(0) || s.String() != "Frozen[int]([])" {
+		t.Errorf("zero value = %v, want empty", &s)
+	}
+	if _, ok := s.Max(); ok {
+		t.Error("Max() on zero value reported ok")
+	}
+}
@@ -0,0 +1,168 @@
+package sets
+
+import (
+	"encoding/json"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+)
+
+// BytesSet is a set of byte slices, e.g. of hashes or binary keys, which cannot be held in a Set directly as []byte is
+// not comparable. Each element is stored as a string of its bytes, so two slices are the same element when they hold
+// the same bytes, and the API takes and returns []byte. It is not a Set[[]byte], so the package-level functions do not
+// apply to it; Strings returns its elements as a *Map[string] for those. It is not ordered and does not guarantee the
+// order of elements when iterating over them. It is not safe for concurrent use.
+//
+// Adding an element copies its bytes once into a string, so the caller may reuse the slice afterwards. Contains and
+// Remove look the slice up without copying or allocating. Iterator, Elements and Pop return copies, which the caller
+// may modify freely; each costs an allocation per element. An empty slice and a nil slice are the same element.
+//
+// BytesSet's zero value is ready to use.
+type BytesSet struct {
+	set map[string]struct{}
+}
+
+// NewBytesSet returns an empty *BytesSet.
+func NewBytesSet() *BytesSet {
+	return &BytesSet{set: make(map[string]struct{})}
+}
+
+// NewBytesSetFrom returns a new *BytesSet filled with the byte slices from the sequence.
+func NewBytesSetFrom(seq iter.Seq[[]byte]) *BytesSet {
+	s := NewBytesSet()
+	for b := range seq {
+		s.Add(b)
+	}
+	return s
+}
+
+// NewBytesSetWith returns a new *BytesSet with the byte slices provided.
+func NewBytesSetWith(b ...[]byte) *BytesSet {
+	return NewBytesSetFrom(slices.Values(b))
+}
+
+// Add a copy of the byte slice to the set. Returns true if it was added, false if the set already held the same bytes.
+func (s *BytesSet) Add(b []byte) bool {
+	if _, ok := s.set[string(b)]; ok {
+		return false
+	}
+	if s.set == nil {
+		s.set = make(map[string]struct{})
+	}
+	s.set[string(b)] = struct{}{}
+	return true
+}
+
+// Remove the byte slice from the set. Returns true if the set held the same bytes.
+func (s *BytesSet) Remove(b []byte) bool {
+	if _, ok := s.set[string(b)]; !ok {
+		return false
+	}
+	delete(s.set, string(b))
+	return true
+}
+
+// Contains returns true if the set holds the same bytes as the byte slice.
+func (s *BytesSet) Contains(b []byte) bool {
+	if s == nil {
+		return false
+	}
+	_, ok := s.set[string(b)]
+	return ok
+}
+
+// Cardinality returns the number of elements in the set.
+func (s *BytesSet) Cardinality() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.set)
+}
+
+// Clear removes all elements from the set and returns the number removed.
+func (s *BytesSet) Clear() int {
+	n := len(s.set)
+	clear(s.set)
+	return n
+}
+
+// Pop removes an element from the set and returns a copy of it. If the set is empty, it returns nil and false.
+func (s *BytesSet) Pop() ([]byte, bool) {
+	for k := range s.set {
+		delete(s.set, k)
+		return []byte(k), true
+	}
+	return nil, false
+}
+
+// Iterator yields a copy of each element in the set. The set must not be modified during iteration.
+func (s *BytesSet) Iterator(yield func([]byte) bool) {
+	if s == nil {
+		return
+	}
+	for k := range s.set {
+		if !yield([]byte(k)) {
+			return
+		}
+	}
+}
+
+// Elements returns copies of the set's elements. Returns nil if the set is empty.
+func (s *BytesSet) Elements() [][]byte {
+	if s.Cardinality() == 0 {
+		return nil
+	}
+	return slices.AppendSeq(make([][]byte, 0, len(s.set)), iter.Seq[[]byte](s.Iterator))
+}
+
+// Strings returns a new *Map[string] holding each element as a string of its bytes, for use with the package-level
+// functions, e.g. Union or Equal between two BytesSets.
+func (s *BytesSet) Strings() *Map[string] {
+	if s == nil {
+		return New[string]()
+	}
+	c := maps.Clone(s.set)
+	if c == nil {
+		c = make(map[string]struct{})
+	}
+	return &Map[string]{set: c, hw: len(c)}
+}
+
+// Clone returns a copy of the set.
+func (s *BytesSet) Clone() *BytesSet {
+	return &BytesSet{set: s.Strings().set}
+}
+
+// String representation of the set. It returns a string of the form BytesSet([<element> ...]), with each element
+// formatted as a byte slice.
+func (s *BytesSet) String() string {
+	return fmt.Sprintf("BytesSet(%v)", s.Elements())
+}
+
+// MarshalJSON implements json.Marshaler. It returns a JSON array of the elements, each base64 encoded as
+// encoding/json encodes a []byte. If the set is empty, it returns an empty JSON array.
+func (s *BytesSet) MarshalJSON() ([]byte, error) {
+	if s.Cardinality() == 0 {
+		return []byte("[]"), nil
+	}
+	d, err := json.Marshal(s.Elements())
+	if err != nil {
+		return d, fmt.Errorf("marshaling bytes set: %w", err)
+	}
+	return d, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It expects a JSON array of base64 encoded elements. A JSON null leaves
+// the set empty. If the JSON is invalid, it returns an error and the set is left unchanged.
+func (s *BytesSet) UnmarshalJSON(d []byte) error {
+	var um [][]byte
+	if err := json.Unmarshal(d, &um); err != nil {
+		return fmt.Errorf("unmarshaling bytes set: %w", err)
+	}
+	s.Clear()
+	for _, b := range um {
+		s.Add(b)
+	}
+	return nil
+}
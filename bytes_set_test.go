@@ -0,0 +1,81 @@
+package sets
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestBytesSet(t *testing.T) {
+	t.Parallel()
+
+	var s BytesSet
+	if s.Cardinality() != 0 || s.Contains(nil) {
+		t.Fatal("zero value BytesSet is not empty")
+	}
+	buf := []byte("abc")
+	if !s.Add(buf) || s.Add([]byte("abc")) {
+		t.Fatal("Add did not dedupe equal byte slices")
+	}
+	buf[0] = 'x' // the set holds a copy
+	if !s.Contains([]byte("abc")) || s.Contains(buf) {
+		t.Fatalf("set changed with the added slice: %v", &s)
+	}
+	if !s.Add(nil) || s.Add([]byte{}) {
+		t.Fatal("nil and empty slices are not the same element")
+	}
+	for b := range s.Iterator {
+		if len(b) > 0 {
+			b[0] = 'y' // yielded slices are copies
+		}
+	}
+	if !s.Contains([]byte("abc")) {
+		t.Fatalf("set changed through an iterated slice: %v", &s)
+	}
+
+	c := s.Clone()
+	if !s.Remove([]byte("abc")) || s.Remove([]byte("abc")) {
+		t.Fatal("Remove did not report the element's presence")
+	}
+	if !c.Contains([]byte("abc")) || !Equal[string](c.Strings(), NewWith("abc", "")) {
+		t.Fatalf("Clone shares elements with the original: %v", c)
+	}
+	if b, ok := s.Pop(); !ok || len(b) != 0 || s.Cardinality() != 0 {
+		t.Fatalf("Pop() = %q, %v, leaving %v", b, ok, &s)
+	}
+	if b, ok := s.Pop(); ok || b != nil {
+		t.Fatalf("Pop() of an empty set = %q, %v", b, ok)
+	}
+	if n := c.Clear(); n != 2 || c.Elements() != nil {
+		t.Fatalf("Clear() = %d, leaving %v", n, c)
+	}
+	if got, want := NewBytesSetWith([]byte{1, 2}).String(), "BytesSet([[1 2]])"; got != want {
+		t.Fatalf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestBytesSet_JSON(t *testing.T) {
+	t.Parallel()
+
+	s := NewBytesSetWith([]byte("a"), []byte{0xff, 0})
+	d, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var c *BytesSet
+	if err := json.Unmarshal(d, &c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := c.Elements()
+	slices.SortFunc(got, bytes.Compare)
+	if want := [][]byte{[]byte("a"), {0xff, 0}}; !slices.EqualFunc(got, want, bytes.Equal) {
+		t.Fatalf("round trip = %q, want %q", got, want)
+	}
+	if err := c.UnmarshalJSON([]byte(`[1]`)); err == nil || c.Cardinality() != 2 {
+		t.Fatalf("UnmarshalJSON([1]) = %v, left %v", err, c)
+	}
+	if d, err := NewBytesSet().MarshalJSON(); err != nil || string(d) != "[]" {
+		t.Fatalf("empty MarshalJSON() = %s, %v", d, err)
+	}
+}
@@ -0,0 +1,95 @@
+package sets
+
+import (
+	"cmp"
+	"context"
+	"iter"
+)
+
+// ctxCheckInterval is how many elements IterateCtx yields between checks of its context.
+const ctxCheckInterval = 256
+
+// IterateCtx returns a sequence that yields the set's elements, in its iteration order, until the context is
+// cancelled, so that request-scoped code can bound the time it spends iterating a large set. The context is checked
+// before the first element and then once every 256 elements, so up to 256 elements may be yielded after cancellation,
+// and none if the context is already cancelled. Cancellation simply ends the sequence early: iter.Seq has no way to
+// report an error, so check ctx.Err() afterwards to tell a cancelled iteration from a complete one.
+func IterateCtx[K comparable](ctx context.Context, s Set[K]) iter.Seq[K] {
+	s = orEmpty(s)
+	return func(yield func(K) bool) {
+		var n int
+		for k := range s.Iterator {
+			if n%ctxCheckInterval == 0 && ctx.Err() != nil {
+				return
+			}
+			n++
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// ToChannel returns a channel that yields every element of the set and is closed once all have been sent or the
+// context is cancelled, bridging sets into channel-based pipelines. The elements are sent from a new goroutine,
+// iterating the set with its Iterator, so they arrive in the set's iteration order. The channel is unbuffered.
+//
+// The goroutine runs until every element has been received or the context is cancelled: a consumer that stops
+// receiving early must cancel the context, or the goroutine leaks. As the set is iterated concurrently with the caller,
+// it must not be modified until the channel is closed unless it is safe for concurrent use. Locked and LockedOrdered
+// iterate over a snapshot taken under the read lock, so the lock is not held while the goroutine waits on the consumer.
+func ToChannel[K comparable](ctx context.Context, s Set[K]) <-chan K {
+	s = orEmpty(s)
+	ch := make(chan K)
+	go func() {
+		defer close(ch)
+		for k := range s.Iterator {
+			select {
+			case ch <- k:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// FromChannel returns a new *Map[K] holding the distinct elements received from the channel, e.g. to collect the
+// distinct results of concurrent producers. It receives until the channel is closed or the context is cancelled and
+// returns the set built so far; check ctx.Err() to tell the two apart.
+func FromChannel[K comparable](ctx context.Context, ch <-chan K) *Map[K] {
+	s := New[K]()
+	receiveInto(ctx, ch, s)
+	return s
+}
+
+// OrderedFromChannel is like FromChannel, but returns an *Ordered[K] holding the distinct elements in the order they
+// were first received.
+func OrderedFromChannel[K cmp.Ordered](ctx context.Context, ch <-chan K) *Ordered[K] {
+	s := NewOrdered[K]()
+	receiveInto(ctx, ch, s)
+	return s
+}
+
+// SyncMapFromChannel is like FromChannel, but returns a *SyncMap[K], so other goroutines may read the set safely once
+// it is returned.
+func SyncMapFromChannel[K comparable](ctx context.Context, ch <-chan K) *SyncMap[K] {
+	s := NewSyncMap[K]()
+	receiveInto(ctx, ch, s)
+	return s
+}
+
+// receiveInto adds the elements received from ch to s until ch is closed or ctx is cancelled.
+func receiveInto[K comparable](ctx context.Context, ch <-chan K, s Set[K]) {
+	for {
+		select {
+		case k, ok := <-ch:
+			if !ok {
+				return
+			}
+			s.Add(k)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
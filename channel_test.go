@@ -0,0 +1,162 @@
+package sets
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestToChannel(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range []Set[int]{nil, NewWith(1, 2, 3), NewOrderedWith(3, 1, 2), NewLockedWith(1, 2, 3)} {
+		var got []int
+		for k := range ToChannel(context.Background(), s) {
+			got = append(got, k)
+		}
+		if len(got) != orEmpty(s).Cardinality() || !Equal(NewWith(got...), s) {
+			t.Errorf("ToChannel(%v) yielded %v", s, got)
+		}
+	}
+
+	var got []int
+	for k := range ToChannel[int](context.Background(), NewOrderedWith(3, 1, 2)) {
+		got = append(got, k)
+	}
+	if !slices.Equal(got, []int{3, 1, 2}) {
+		t.Errorf("ToChannel of an ordered set yielded %v, want [3 1 2]", got)
+	}
+}
+
+func TestToChannel_Cancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := ToChannel[int](ctx, NewLockedWith(genInts(100)...))
+	<-ch
+	cancel()
+
+	// the goroutine stops sending and closes the channel; at most one more element may already be in flight
+	timeout := time.After(5 * time.Second)
+	var n int
+	for {
+		select {
+		case _, ok := <-ch:
+			if !ok {
+				if n > 1 {
+					t.Fatalf("received %d elements after cancellation", n)
+				}
+				return
+			}
+			n++
+		case <-timeout:
+			t.Fatal("channel not closed after cancellation")
+		}
+	}
+}
+
+func TestFromChannel(t *testing.T) {
+	t.Parallel()
+
+	// several producers send overlapping results
+	ch := make(chan int)
+	var wg sync.WaitGroup
+	for p := range 4 {
+		wg.Go(func() {
+			for i := range 50 {
+				ch <- p*25 + i
+			}
+		})
+	}
+	go func() {
+		wg.Wait()
+		close(ch)
+	}()
+
+	got := FromChannel(context.Background(), ch)
+	if want := NewWith(genInts(125)...); !Equal[int](got, want) {
+		t.Fatalf("FromChannel = %v, want 0..124", got)
+	}
+}
+
+func TestFromChannel_Variants(t *testing.T) {
+	t.Parallel()
+
+	send := func(vs ...int) <-chan int {
+		ch := make(chan int, len(vs))
+		for _, v := range vs {
+			ch <- v
+		}
+		close(ch)
+		return ch
+	}
+	if got := Elements[int](OrderedFromChannel(context.Background(), send(3, 1, 3, 2, 1))); !slices.Equal(got, []int{3, 1, 2}) {
+		t.Errorf("OrderedFromChannel = %v, want [3 1 2]", got)
+	}
+	if got := SyncMapFromChannel(context.Background(), send(3, 1, 3)); !Equal[int](got, NewWith(1, 3)) {
+		t.Errorf("SyncMapFromChannel = %v, want {1 3}", got)
+	}
+}
+
+func TestFromChannel_Cancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan int)
+	go func() {
+		ch <- 1
+		ch <- 2
+		cancel() // the channel is never closed
+	}()
+
+	done := make(chan *Map[int])
+	go func() { done <- FromChannel(ctx, ch) }()
+	select {
+	case got := <-done:
+		if !Equal[int](got, NewWith(1, 2)) {
+			t.Fatalf("FromChannel = %v, want {1 2}", got)
+		}
+		if ctx.Err() == nil {
+			t.Fatal("expected the context to be cancelled")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("FromChannel did not return after cancellation")
+	}
+}
+
+func TestIterateCtx(t *testing.T) {
+	t.Parallel()
+
+	if got := slices.Collect(IterateCtx[int](context.Background(), NewOrderedWith(3, 1, 2))); !slices.Equal(got, []int{3, 1, 2}) {
+		t.Errorf("IterateCtx of an ordered set yielded %v, want [3 1 2]", got)
+	}
+	if got := slices.Collect(IterateCtx[int](context.Background(), nil)); len(got) != 0 {
+		t.Errorf("IterateCtx of a nil set yielded %v", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if got := slices.Collect(IterateCtx[int](ctx, NewWith(1, 2, 3))); len(got) != 0 {
+		t.Errorf("IterateCtx with a cancelled context yielded %v", got)
+	}
+
+	// cancelled part way through: the sequence stops at the next check
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	var n int
+	for range IterateCtx[int](ctx, NewOrderedWith(genInts(10*ctxCheckInterval)...)) {
+		if n++; n == ctxCheckInterval+10 {
+			cancel()
+		}
+	}
+	if n != 2*ctxCheckInterval {
+		t.Errorf("IterateCtx yielded %d elements when cancelled after %d, want %d", n, ctxCheckInterval+10, 2*ctxCheckInterval)
+	}
+
+	// stopping early is honored
+	for range IterateCtx[int](context.Background(), NewWith(1, 2, 3)) {
+		break
+	}
+}
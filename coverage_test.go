@@ -3,9 +3,20 @@ package sets
 import (
 	"cmp"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"maps"
 	"math"
+	"math/rand/v2"
+	"reflect"
 	"slices"
+	"strings"
 	"testing"
+	"time"
+	"unicode/utf8"
+
+	"pgregory.net/rapid"
 )
 
 // plainSet delegates to an embedded Set but does not implement json.Marshaler,
@@ -38,6 +49,28 @@ func TestFilterTo(t *testing.T) {
 	}
 }
 
+// TestFilter_LockedSnapshot checks that Filter calls the predicate without holding a locked set's lock: the predicate
+// writes to the set it is filtering, which would deadlock otherwise, and the filter sees only the snapshot.
+func TestFilter_LockedSnapshot(t *testing.T) {
+	t.Parallel()
+
+	for name, s := range map[string]Set[int]{
+		"Locked":        NewLockedWith(1, 2, 3, 4),
+		"LockedOrdered": NewLockedOrderedWith(1, 2, 3, 4),
+	} {
+		got := Filter(s, func(i int) bool {
+			s.Add(i + 10)
+			return i%2 == 0
+		})
+		if !Equal(got, NewWith(2, 4)) {
+			t.Errorf("%s: Filter = %v, want [2 4]", name, Elements(got))
+		}
+		if s.Cardinality() != 8 {
+			t.Errorf("%s: Cardinality = %d after writes from the predicate, want 8", name, s.Cardinality())
+		}
+	}
+}
+
 func TestString(t *testing.T) {
 	t.Parallel()
 
@@ -128,9 +161,10 @@ func TestNilReceiverCardinality(t *testing.T) {
 	var l *Locked[int]
 	var lo *LockedOrdered[int]
 	var ss *SortedSet[int]
+	var b *Bounded[int]
 	for i, c := range []int{
 		m.Cardinality(), o.Cardinality(), sm.Cardinality(),
-		l.Cardinality(), lo.Cardinality(), ss.Cardinality(),
+		l.Cardinality(), lo.Cardinality(), ss.Cardinality(), b.Cardinality(),
 	} {
 		if c != 0 {
 			t.Fatalf("nil receiver Cardinality() #%d = %d, want 0", i, c)
@@ -187,6 +221,10 @@ func TestZeroValueWrappers(t *testing.T) {
 		t.Fatalf("zero value Locked.Scan error: %v", err)
 	}
 
+	if d, err := l.MarshalJSON(); err != nil || string(d) != "[]" {
+		t.Fatalf("zero value Locked.MarshalJSON = %s, %v, want []", d, err)
+	}
+
 	var lo LockedOrdered[int]
 	if c := lo.Clone(); c.Cardinality() != 0 {
 		t.Fatalf("zero value LockedOrdered.Clone() has %d elements", c.Cardinality())
@@ -299,8 +337,15 @@ func TestLockedWrappingNonJSONSet(t *testing.T) {
 	t.Parallel()
 
 	w := NewLockedWrapping(plainSet[int]{New[int]()})
-	if _, err := w.(json.Marshaler).MarshalJSON(); err == nil {
-		t.Fatal("MarshalJSON of a wrapped non-marshaler set did not error")
+	if d, err := w.(json.Marshaler).MarshalJSON(); err != nil || string(d) != "[]" {
+		t.Fatalf("MarshalJSON of an empty wrapped non-marshaler set = %s, %v, want []", d, err)
+	}
+	w.Add(7)
+	if d, err := w.(json.Marshaler).MarshalJSON(); err != nil || string(d) != "[7]" {
+		t.Fatalf("MarshalJSON of a wrapped non-marshaler set = %s, %v, want [7]", d, err)
+	}
+	if _, err := NewLockedWrapping(plainSet[float64]{NewWith(math.NaN())}).(json.Marshaler).MarshalJSON(); err == nil {
+		t.Fatal("MarshalJSON of a wrapped non-marshaler set holding NaN did not error")
 	}
 	if err := w.(json.Unmarshaler).UnmarshalJSON([]byte(`[1]`)); err == nil {
 		t.Fatal("UnmarshalJSON of a wrapped non-unmarshaler set did not error")
@@ -309,9 +354,9 @@ func TestLockedWrappingNonJSONSet(t *testing.T) {
 		t.Fatal("Scan of a wrapped non-unmarshaler set did not error")
 	}
 
-	wo := NewLockedOrderedWrapping(plainOrdered[int]{NewOrdered[int]()})
-	if _, err := wo.(json.Marshaler).MarshalJSON(); err == nil {
-		t.Fatal("MarshalJSON of a wrapped non-marshaler ordered set did not error")
+	wo := NewLockedOrderedWrapping(plainOrdered[int]{NewOrderedWith(3, 1, 2)})
+	if d, err := wo.(json.Marshaler).MarshalJSON(); err != nil || string(d) != "[3,1,2]" {
+		t.Fatalf("MarshalJSON of a wrapped non-marshaler ordered set = %s, %v, want [3,1,2]", d, err)
 	}
 	if err := wo.(json.Unmarshaler).UnmarshalJSON([]byte(`[1]`)); err == nil {
 		t.Fatal("UnmarshalJSON of a wrapped non-unmarshaler ordered set did not error")
@@ -441,3 +486,1680 @@ func TestNilReceiverPredicates(t *testing.T) {
 		t.Fatal("BitSet Subset(nil) reported handled")
 	}
 }
+
+// TestGrowPreservesContents pins that presizing a non-empty destination (which copies Go maps,
+// since they cannot grow in place) keeps its elements and, for Ordered, their order.
+func TestGrowPreservesContents(t *testing.T) {
+	t.Parallel()
+
+	m := NewWith(1, 2, 3)
+	grow[int](m, 100)
+	if !Equal[int](m, NewWith(1, 2, 3)) {
+		t.Fatalf("Map grow changed contents: %v", Elements[int](m))
+	}
+	m.Add(4)
+	if !m.Contains(4) {
+		t.Fatal("Map lost an Add after grow")
+	}
+
+	o := NewOrderedWith(3, 1, 2)
+	o.Remove(1)
+	grow[int](o, 100)
+	o.Add(5)
+	if got := slices.Collect(o.Iterator); !slices.Equal(got, []int{3, 2, 5}) {
+		t.Fatalf("Ordered grow changed order: %v", got)
+	}
+	if o.Index(5) != 2 {
+		t.Fatalf("Ordered Index(5) = %d after grow, want 2", o.Index(5))
+	}
+
+	u := Union[int](NewOrderedWith(3, 1), NewOrderedWith(1, 2))
+	if got := Elements(u); !slices.Equal(got, []int{3, 1, 2}) {
+		t.Fatalf("Ordered Union = %v, want [3 1 2]", got)
+	}
+}
+
+func TestEqualWithin(t *testing.T) {
+	t.Parallel()
+
+	if !EqualWithin[float64](New[float64](), NewSortedSet[float64](), 0) {
+		t.Fatal("empty sets are not EqualWithin")
+	}
+	if EqualWithin[float64](NewWith(1.0, 2.0), NewWith(1.0), 10) {
+		t.Fatal("sets of different cardinality are EqualWithin")
+	}
+	// 1.0 is within 0.6 of 1.5 but pairing it there would leave 2.0 without a partner
+	if !EqualWithin[float64](NewWith(1.0, 2.0), NewWith(1.5, 2.5), 0.6) {
+		t.Fatal("sorted pairing not found")
+	}
+	if EqualWithin[float64](NewWith(math.NaN()), NewWith(math.NaN()), math.Inf(1)) {
+		t.Fatal("NaN is EqualWithin NaN")
+	}
+	if !EqualWithin[float32](NewWith[float32](-1, 1), NewWith[float32](1.0001, -0.9999), 0.001) {
+		t.Fatal("float32 sets are not EqualWithin")
+	}
+	if !EqualWithin[float64](nil, New[float64](), 0.1) || EqualWithin[float64](NewWith(1.0), nil, 0.1) {
+		t.Fatal("EqualWithin does not treat a nil set as empty")
+	}
+	inf := math.Inf(1)
+	if !EqualWithin[float64](NewWith(-inf, 1, inf), NewWith(1.1, inf, -inf), 0.2) {
+		t.Fatal("sets holding the same infinities are not EqualWithin")
+	}
+	if EqualWithin[float64](NewWith(inf), NewWith(-inf), 1) || EqualWithin[float64](NewWith(inf), NewWith(1e308), 1) {
+		t.Fatal("an infinity is EqualWithin a different element")
+	}
+}
+
+// TestNilSetArguments pins that the two-set package functions treat both a nil interface and a
+// typed nil as an empty set instead of panicking.
+func TestNilSetArguments(t *testing.T) {
+	t.Parallel()
+
+	s := NewWith(1, 2)
+	if got := Union[int](nil, s); !Equal[int](got, s) {
+		t.Fatalf("Union(nil, s) = %v, want %v", Elements(got), Elements[int](s))
+	}
+	if !Subset[int](nil, s) {
+		t.Fatal("Subset(nil, s) = false, want true")
+	}
+	if Superset[int](nil, s) {
+		t.Fatal("Superset(nil, s) = true, want false")
+	}
+	if !Equal[int](nil, New[int]()) {
+		t.Fatal("Equal(nil, New()) = false, want true")
+	}
+	if !Disjoint[int](s, nil) {
+		t.Fatal("Disjoint(s, nil) = false, want true")
+	}
+
+	typedNils := []Set[int]{
+		(*Map[int])(nil), (*Ordered[int])(nil), (*SortedSet[int])(nil), (*BitSet[int])(nil),
+		(*SyncMap[int])(nil), (*Locked[int])(nil), (*LockedOrdered[int])(nil), (*Bag[int])(nil),
+		(*Bounded[int])(nil),
+	}
+	for _, n := range typedNils {
+		if got := Union(n, s); !Equal[int](got, s) {
+			t.Fatalf("Union(%T(nil), s) = %v", n, Elements(got))
+		}
+		if got := Union[int](s, n); !Equal[int](got, s) {
+			t.Fatalf("Union(s, %T(nil)) = %v", n, Elements(got))
+		}
+		if got := Intersection(n, s); !IsEmpty(got) {
+			t.Fatalf("Intersection(%T(nil), s) = %v", n, Elements(got))
+		}
+		if got := Difference[int](s, n); !Equal[int](got, s) {
+			t.Fatalf("Difference(s, %T(nil)) = %v", n, Elements(got))
+		}
+		if got := SymmetricDifference(n, s); !Equal[int](got, s) {
+			t.Fatalf("SymmetricDifference(%T(nil), s) = %v", n, Elements(got))
+		}
+		if !Subset(n, s) || Subset[int](s, n) {
+			t.Fatalf("Subset with %T(nil) is wrong", n)
+		}
+		if !Equal(n, New[int]()) || Equal[int](s, n) {
+			t.Fatalf("Equal with %T(nil) is wrong", n)
+		}
+		if !Disjoint(n, s) {
+			t.Fatalf("Disjoint(%T(nil), s) = false", n)
+		}
+	}
+	var b *Bounded[int]
+	if b.Limit() != 0 || b.Clear() != 0 || b.Drain() != nil || b.Remove(1) || b.RemoveAll(s) != 0 || b.Keep(s) != 0 ||
+		b.Index(1) != -1 || b.IndexFunc(func(int) bool { return true }) != -1 || b.MoveToFront(1) || b.MoveToBack(1) {
+		t.Fatal("nil Bounded is not empty")
+	}
+	if _, ok := b.Pop(); ok {
+		t.Fatal("nil Bounded Pop() reported an element")
+	}
+	if d, err := b.MarshalJSON(); err != nil || string(d) != "[]" || b.String() != "BoundedSet[int]([])" {
+		t.Fatalf("nil Bounded MarshalJSON() = %s, %v; String() = %s", d, err, b.String())
+	}
+	if _, ok := b.NewEmptyOrdered().(*Ordered[int]); !ok {
+		t.Fatalf("nil Bounded NewEmptyOrdered() = %T, want *Ordered[int]", b.NewEmptyOrdered())
+	}
+	b.Sort()
+	// typed nils of the fast-path types decline to the generic path against each other
+	if got := Union[int]((*SortedSet[int])(nil), NewSortedSetWith(1)); !Equal[int](got, NewWith(1)) {
+		t.Fatalf("Union(nil SortedSet, SortedSet) = %v", Elements(got))
+	}
+	if got := Intersection[int](NewBitSetWith(1), (*BitSet[int])(nil)); !IsEmpty(got) {
+		t.Fatalf("Intersection(BitSet, nil BitSet) = %v", Elements(got))
+	}
+}
+
+// TestContainsSeqEmpty pins ContainsSeq for all four combinations of empty and non-empty set and sequence: an empty
+// sequence is contained by every set, and an empty set contains no non-empty sequence.
+func TestContainsSeqEmpty(t *testing.T) {
+	t.Parallel()
+
+	impls := map[string]func(...int) Set[int]{
+		"Map":       func(v ...int) Set[int] { return NewWith(v...) },
+		"Ordered":   func(v ...int) Set[int] { return NewOrderedWith(v...) },
+		"SortedSet": func(v ...int) Set[int] { return NewSortedSetWith(v...) },
+		"BitSet":    func(v ...int) Set[int] { return NewBitSetWith(v...) },
+		"SyncMap":   func(v ...int) Set[int] { return NewSyncMapWith(v...) },
+		"Locked":    func(v ...int) Set[int] { return NewLockedWith(v...) },
+	}
+	for name, newSet := range impls {
+		for _, tc := range []struct {
+			set  []int
+			seq  []int
+			want bool
+		}{
+			{set: nil, seq: nil, want: true},
+			{set: nil, seq: []int{1}, want: false},
+			{set: []int{1, 2}, seq: nil, want: true},
+			{set: []int{1, 2}, seq: []int{2}, want: true},
+		} {
+			if got := ContainsSeq(newSet(tc.set...), slices.Values(tc.seq)); got != tc.want {
+				t.Errorf("%s: ContainsSeq(%v, %v) = %v, want %v", name, tc.set, tc.seq, got, tc.want)
+			}
+		}
+	}
+}
+
+// TestLazySetAlgebra checks the iterator forms of Union, Intersection, and Difference against the set-building forms,
+// including their order for ordered operands and early termination.
+func TestLazySetAlgebra(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(t *rapid.T) {
+		a := NewOrderedWith(rapid.SliceOf(rapid.IntRange(0, 30)).Draw(t, "a")...)
+		b := NewOrderedWith(rapid.SliceOf(rapid.IntRange(0, 30)).Draw(t, "b")...)
+
+		for _, tc := range []struct {
+			name string
+			lazy iter.Seq[int]
+			want Set[int]
+		}{
+			{"UnionIter", UnionIter[int](a, b), Union[int](a, b)},
+			{"IntersectionIter", IntersectionIter[int](a, b), Intersection[int](a, b)},
+			{"DifferenceIter", DifferenceIter[int](a, b), Difference[int](a, b)},
+		} {
+			got := slices.Collect(tc.lazy)
+			if !slices.Equal(got, Elements(tc.want)) {
+				t.Fatalf("%s = %v, want %v", tc.name, got, Elements(tc.want))
+			}
+			if again := slices.Collect(tc.lazy); !slices.Equal(again, got) {
+				t.Fatalf("%s second iteration = %v, want %v", tc.name, again, got)
+			}
+			if len(got) > 0 {
+				var first []int
+				for v := range tc.lazy {
+					first = append(first, v)
+					break
+				}
+				if !slices.Equal(first, got[:1]) {
+					t.Fatalf("%s stopped early = %v, want %v", tc.name, first, got[:1])
+				}
+			}
+		}
+	})
+
+	if got := slices.Collect(UnionIter(nil, NewWith(1))); !slices.Equal(got, []int{1}) {
+		t.Fatalf("UnionIter(nil, {1}) = %v, want [1]", got)
+	}
+	if got := slices.Collect(DifferenceIter(NewWith(1), nil)); !slices.Equal(got, []int{1}) {
+		t.Fatalf("DifferenceIter({1}, nil) = %v, want [1]", got)
+	}
+}
+
+// TestUnionIterDedup pins that UnionIter suppresses elements of b already in a, for every implementation.
+func TestUnionIterDedup(t *testing.T) {
+	t.Parallel()
+
+	for _, a := range []Set[int]{NewWith(1, 2, 3), NewOrderedWith(1, 2, 3), NewSortedSetWith(1, 2, 3),
+		NewBitSetWith(1, 2, 3), NewSyncMapWith(1, 2, 3), NewLockedWith(1, 2, 3)} {
+		got := slices.Sorted(UnionIter(a, NewWith(2, 3, 4)))
+		if !slices.Equal(got, []int{1, 2, 3, 4}) {
+			t.Errorf("UnionIter(%T{1 2 3}, {2 3 4}) = %v, want [1 2 3 4]", a, got)
+		}
+		if got := slices.Collect(IntersectionIter(a, NewWith(2, 3, 4))); len(got) != 2 {
+			t.Errorf("IntersectionIter(%T{1 2 3}, {2 3 4}) = %v, want 2 elements", a, got)
+		}
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(t *rapid.T) {
+		s := NewWith(rapid.SliceOf(rapid.IntRange(-100, 100)).Draw(t, "elements")...)
+		n := rapid.IntRange(1, 10).Draw(t, "buckets")
+		bucket := func(v int) int { return ((v % n) + n) % n }
+
+		h := Histogram(s, bucket)
+		var total int
+		for b, c := range h {
+			if want := Filter(s, func(v int) bool { return bucket(v) == b }).Cardinality(); c != want {
+				t.Fatalf("bucket %d: count %d, want %d", b, c, want)
+			}
+			total += c
+		}
+		if total != s.Cardinality() {
+			t.Fatalf("counts sum to %d, want cardinality %d", total, s.Cardinality())
+		}
+	})
+
+	if h := Histogram(New[int](), func(v int) int { return v }); h == nil || len(h) != 0 {
+		t.Fatalf("Histogram of an empty set = %#v, want an empty map", h)
+	}
+}
+
+// TestLockedWrappingClone pins that cloning a wrapper clones the inner set, keeping its concrete type and order.
+func TestLockedWrappingClone(t *testing.T) {
+	t.Parallel()
+
+	l := NewLockedWrapping[int](NewOrderedWith(3, 1, 2)).(*Locked[int])
+	c := l.Clone().(*Locked[int])
+	if _, ok := c.set.(*Ordered[int]); !ok {
+		t.Fatalf("Locked clone wraps %T, want *Ordered[int]", c.set)
+	}
+	if got := Elements[int](c); !slices.Equal(got, []int{3, 1, 2}) {
+		t.Fatalf("Locked clone elements = %v, want [3 1 2]", got)
+	}
+	if _, ok := l.NewEmpty().(*Locked[int]).set.(*Ordered[int]); !ok {
+		t.Fatal("Locked NewEmpty does not wrap an *Ordered[int]")
+	}
+	c.Add(4)
+	if l.Contains(4) {
+		t.Fatal("Locked clone shares its inner set with the original")
+	}
+
+	for _, inner := range []OrderedSet[int]{NewOrderedWith(3, 1, 2), NewSortedSetWith(3, 1, 2), NewBounded[int](5)} {
+		AppendSeq(inner, slices.Values([]int{3, 1, 2}))
+		lo := NewLockedOrderedWrapping(inner).(*LockedOrdered[int])
+		co := lo.Clone().(*LockedOrdered[int])
+		if fmt.Sprintf("%T", co.set) != fmt.Sprintf("%T", inner) {
+			t.Fatalf("LockedOrdered clone wraps %T, want %T", co.set, inner)
+		}
+		if !EqualOrdered[int](co, lo) {
+			t.Fatalf("LockedOrdered clone of %T = %v, want %v", inner, Elements[int](co), Elements[int](lo))
+		}
+	}
+}
+
+func TestAppendSeqErr(t *testing.T) {
+	t.Parallel()
+
+	errBoom := errors.New("boom")
+	pairs := func(vals []int, failAt int) iter.Seq2[int, error] {
+		return func(yield func(int, error) bool) {
+			for i, v := range vals {
+				var err error
+				if i == failAt {
+					err = errBoom
+				}
+				if !yield(v, err) {
+					return
+				}
+			}
+		}
+	}
+
+	s := New[int]()
+	if n, err := AppendSeqErr(s, pairs([]int{1, 2, 2, 3}, -1)); n != 3 || err != nil {
+		t.Fatalf("AppendSeqErr without errors = %d, %v, want 3, nil", n, err)
+	}
+
+	s = New[int]()
+	n, err := AppendSeqErr(s, pairs([]int{1, 2, 3, 4}, 2))
+	if n != 2 || !errors.Is(err, errBoom) {
+		t.Fatalf("AppendSeqErr = %d, %v, want 2, boom", n, err)
+	}
+	if got := slices.Sorted(s.Iterator); !slices.Equal(got, []int{1, 2}) {
+		t.Fatalf("elements = %v, want [1 2]: the failing pair and those after it must not be added", got)
+	}
+}
+
+// TestEqualFunc covers EqualFunc for each combination of order sensitivity, ordered and unordered operands, and same
+// and different order.
+func TestEqualFunc(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name           string
+		a, b           Set[int]
+		orderSensitive bool
+		want           bool
+	}{
+		{"insensitive/ordered same order", NewOrderedWith(1, 2, 3), NewOrderedWith(1, 2, 3), false, true},
+		{"insensitive/ordered different order", NewOrderedWith(1, 2, 3), NewOrderedWith(3, 2, 1), false, true},
+		{"insensitive/different elements", NewOrderedWith(1, 2), NewWith(1, 3), false, false},
+		{"sensitive/ordered same order", NewOrderedWith(1, 2, 3), NewSortedSetWith(3, 2, 1), true, true},
+		{"sensitive/ordered different order", NewOrderedWith(3, 2, 1), NewSortedSetWith(1, 2, 3), true, false},
+		{"sensitive/ordered different cardinality", NewOrderedWith(1, 2), NewOrderedWith(1, 2, 3), true, false},
+		{"sensitive/locked ordered", NewLockedOrderedWith(2, 1), NewOrderedWith(2, 1), true, true},
+		{"sensitive/ordered vs unordered", NewOrderedWith(3, 2, 1), NewWith(1, 2, 3), true, true},
+		{"sensitive/unordered vs ordered", NewWith(1, 2, 3), NewOrderedWith(3, 2, 1), true, true},
+		{"sensitive/unordered vs unordered", NewWith(1, 2, 3), NewLockedWith(3, 2, 1), true, true},
+		{"sensitive/unordered different elements", NewWith(1, 2), NewOrderedWith(2, 3), true, false},
+		{"sensitive/nil vs empty ordered", nil, NewOrdered[int](), true, true},
+	} {
+		if got := EqualFunc(tc.a, tc.b, tc.orderSensitive); got != tc.want {
+			t.Errorf("%s: EqualFunc(%v, %v, %v) = %v, want %v", tc.name, tc.a, tc.b, tc.orderSensitive, got, tc.want)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		a, b Set[int]
+		want string
+	}{
+		{NewWith(3, 1, 2), NewOrderedWith(2, 3, 1), "only in a: []\nonly in b: []\ncommon: 3"},
+		{NewOrderedWith(9, 2, 7, 1), NewWith(1, 8, 3), "only in a: [2 7 9]\nonly in b: [3 8]\ncommon: 1"},
+		{nil, NewSortedSetWith(2, 1), "only in a: []\nonly in b: [1 2]\ncommon: 0"},
+		{NewBitSetWith(4, 5), nil, "only in a: [4 5]\nonly in b: []\ncommon: 0"},
+	} {
+		if got := Compare(tc.a, tc.b); got != tc.want {
+			t.Errorf("Compare(%v, %v) =\n%s\nwant\n%s", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+// TestSplitDifference checks, for every pairing of set types, that SplitDifference's three partitions are pairwise
+// disjoint, union to a ∪ b, and match Difference and Intersection, with the type of a.NewEmpty().
+func TestSplitDifference(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		as := rapid.SliceOfN(rapid.IntRange(0, 12), 0, 8).Draw(t, "a")
+		bs := rapid.SliceOfN(rapid.IntRange(0, 12), 0, 8).Draw(t, "b")
+		for an, newA := range intSetConstructors {
+			for bn, newB := range intSetConstructors {
+				a, b := newA(as...), newB(bs...)
+				onlyA, both, onlyB := SplitDifference(a, b)
+				if !DisjointAll(onlyA, both, onlyB) {
+					t.Fatalf("%s/%s: partitions %v, %v, %v are not disjoint", an, bn, onlyA, both, onlyB)
+				}
+				if u := Union(Union(onlyA, both), onlyB); !Equal(u, Union(NewWith(as...), NewWith(bs...))) {
+					t.Fatalf("%s/%s: partitions union to %v, want a ∪ b", an, bn, u)
+				}
+				if !Equal(onlyA, Difference(a, b)) || !Equal(both, Intersection(a, b)) || !Equal(onlyB, Difference(b, a)) {
+					t.Fatalf("%s/%s: SplitDifference = %v, %v, %v", an, bn, onlyA, both, onlyB)
+				}
+				for _, p := range []Set[int]{onlyA, both, onlyB} {
+					if reflect.TypeOf(p) != reflect.TypeOf(a.NewEmpty()) {
+						t.Fatalf("%s/%s: partition type %T, want %T", an, bn, p, a.NewEmpty())
+					}
+				}
+			}
+		}
+	})
+	if onlyA, both, onlyB := SplitDifference[int](nil, NewWith(1)); onlyA.Cardinality() != 0 || both.Cardinality() != 0 ||
+		!Equal(onlyB, NewWith(1)) {
+		t.Fatalf("SplitDifference(nil, [1]) = %v, %v, %v", onlyA, both, onlyB)
+	}
+}
+
+func TestCloneFunc(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range []Set[int]{NewWith(3, 1, 2), NewOrderedWith(3, 1, 2), NewSortedSetWith(3, 1, 2),
+		NewLockedOrderedWith(3, 1, 2), NewBitSetWith(3, 1, 2)} {
+		c := CloneFunc(s, func(v int) int { return v * 10 })
+		if fmt.Sprintf("%T", c) != fmt.Sprintf("%T", s) {
+			t.Errorf("CloneFunc(%T) returned a %T", s, c)
+		}
+		want := MapToSlice(s, func(v int) int { return v * 10 })
+		if _, ordered := s.(OrderedSet[int]); !ordered {
+			slices.Sort(want)
+			c = NewSortedSetFrom(c.Iterator)
+		}
+		if got := Elements(c); !slices.Equal(got, want) {
+			t.Errorf("CloneFunc(%v) = %v, want %v", s, got, want)
+		}
+	}
+
+	if c := CloneFunc(NewOrderedWith(1, 2, 3), func(int) int { return 0 }); c.Cardinality() != 1 {
+		t.Errorf("CloneFunc mapping every element to 0 = %v, want one element", c)
+	}
+}
+
+func TestIntersectionSeqs(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(t *rapid.T) {
+		a := NewOrderedWith(rapid.SliceOf(rapid.IntRange(0, 20)).Draw(t, "a")...)
+		seqs := rapid.SliceOfN(rapid.SliceOf(rapid.IntRange(0, 20)), 0, 4).Draw(t, "seqs")
+
+		want := Set[int](a)
+		iters := make([]iter.Seq[int], 0, len(seqs))
+		for _, seq := range seqs {
+			want = Intersection[int](want, NewWith(seq...))
+			iters = append(iters, slices.Values(seq))
+		}
+		got := IntersectionSeqs[int](a, iters...)
+		if !slices.Equal(Elements(got), Elements(want)) {
+			t.Fatalf("IntersectionSeqs = %v, want %v", Elements(got), Elements(want))
+		}
+		if got == Set[int](a) {
+			t.Fatal("IntersectionSeqs returned a itself, want a new set")
+		}
+	})
+
+	// once nothing is common, later sequences are not consumed
+	consumed := false
+	lazy := func(func(int) bool) { consumed = true }
+	if got := IntersectionSeqs(NewWith(1), slices.Values([]int{2}), lazy); !IsEmpty(got) || consumed {
+		t.Fatalf("IntersectionSeqs = %v, consumed the last sequence: %v", Elements(got), consumed)
+	}
+}
+
+// iterCountingSet counts calls to Iterator and Contains on an embedded set.
+type iterCountingSet struct {
+	Set[int]
+	calls int
+}
+
+func (s *iterCountingSet) Iterator(yield func(int) bool) {
+	s.calls++
+	s.Set.Iterator(yield)
+}
+
+func (s *iterCountingSet) Contains(k int) bool {
+	s.calls++
+	return s.Set.Contains(k)
+}
+
+// uncomparableSet is a Set whose dynamic type can't be compared with ==.
+type uncomparableSet struct {
+	Set[int]
+	_ []int
+}
+
+// TestPredicateIdentity pins the identity fast path: the predicates answer for a set compared with itself without
+// iterating it, and sets of uncomparable types are still compared element-wise.
+func TestPredicateIdentity(t *testing.T) {
+	t.Parallel()
+
+	s := &iterCountingSet{Set: NewWith(1, 2, 3)}
+	if !Subset[int](s, s) || !Superset[int](s, s) || !Equal[int](s, s) {
+		t.Fatal("a set must be a subset, superset, and equal to itself")
+	}
+	if s.calls != 0 {
+		t.Fatalf("Subset/Superset/Equal(s, s) made %d Iterator/Contains calls, want 0", s.calls)
+	}
+	if Disjoint[int](s, s) {
+		t.Fatal("a non-empty set is not disjoint from itself")
+	}
+	if s.calls != 1 {
+		t.Fatalf("Disjoint(s, s) made %d Iterator/Contains calls, want 1", s.calls)
+	}
+	empty := &iterCountingSet{Set: New[int]()}
+	if !Disjoint[int](empty, empty) {
+		t.Fatal("an empty set is disjoint from itself")
+	}
+
+	u := uncomparableSet{Set: NewWith(1, 2)}
+	if !Equal[int](u, u) || !Subset[int](u, u) || Disjoint[int](u, u) {
+		t.Fatal("wrong predicate result for a set of an uncomparable type")
+	}
+}
+
+func TestSortedSlice(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range []Set[int]{NewWith(3, 1, 2), NewOrderedWith(3, 1, 2), NewSortedSetWith(3, 1, 2),
+		NewBitSetWith(3, 1, 2), NewLockedWith(3, 1, 2)} {
+		if got := SortedSlice(s); !slices.Equal(got, []int{1, 2, 3}) {
+			t.Errorf("SortedSlice(%T) = %v, want [1 2 3]", s, got)
+		}
+		if got := SortedSlice(s.NewEmpty()); got != nil {
+			t.Errorf("SortedSlice(empty %T) = %v, want nil", s, got)
+		}
+	}
+
+	ss := NewSortedSetWith(1, 2)
+	SortedSlice[int](ss)[0] = 9
+	if !ss.Contains(1) {
+		t.Fatal("SortedSlice shares the SortedSet's backing slice")
+	}
+}
+
+func TestElementsOrdered(t *testing.T) {
+	if got := ElementsOrdered[int](NewOrdered[int]()); got != nil {
+		t.Fatalf("ElementsOrdered(empty) = %v, want nil", got)
+	}
+	for _, s := range []OrderedSet[int]{
+		NewOrderedWith(4, 2, 9),
+		NewLockedOrderedWith(4, 2, 9),
+		NewSortedSetWith(4, 2, 9),
+	} {
+		got := ElementsOrdered(s)
+		if !slices.Equal(got, Elements[int](s)) {
+			t.Fatalf("%T: ElementsOrdered = %v, want %v", s, got, Elements[int](s))
+		}
+		if cap(got) != s.Cardinality() {
+			t.Fatalf("%T: cap = %d, want %d", s, cap(got), s.Cardinality())
+		}
+	}
+}
+
+func TestNewFromString(t *testing.T) {
+	if got := NewFromString(""); got.Cardinality() != 0 {
+		t.Fatalf("NewFromString(\"\") has %d elements, want 0", got.Cardinality())
+	}
+	const str = "héllo, wörld\xff"
+	want := []rune{'h', 'é', 'l', 'o', ',', ' ', 'w', 'ö', 'r', 'd', utf8.RuneError}
+	if got := Elements[rune](NewOrderedFromString(str)); !slices.Equal(got, want) {
+		t.Fatalf("NewOrderedFromString = %q, want %q", got, want)
+	}
+	if !Equal[rune](NewFromString(str), NewWith(want...)) {
+		t.Fatalf("NewFromString = %v, want the runes %q", NewFromString(str), want)
+	}
+}
+
+func TestDisjointAll(t *testing.T) {
+	a, b, c := NewWith(1, 2), NewOrderedWith(3, 4), NewSortedSetWith(5)
+	tests := []struct {
+		name string
+		sets []Set[int]
+		want bool
+	}{
+		{"none", nil, true},
+		{"one", []Set[int]{a}, true},
+		{"pairwise disjoint", []Set[int]{a, b, c}, true},
+		{"with nil and empty", []Set[int]{a, nil, New[int](), b}, true},
+		{"overlap in last pair", []Set[int]{a, b, NewWith(4, 9)}, false},
+		{"same set twice", []Set[int]{a, b, a}, false},
+		{"same empty set twice", []Set[int]{New[int](), New[int]()}, true},
+	}
+	for _, tt := range tests {
+		if got := DisjointAll(tt.sets...); got != tt.want {
+			t.Errorf("%s: DisjointAll = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	rapid.Check(t, func(t *rapid.T) {
+		var sets []Set[int]
+		for range rapid.IntRange(0, 5).Draw(t, "n") {
+			sets = append(sets, NewFrom(slices.Values(rapid.SliceOfN(rapid.IntRange(0, 30), 0, 5).Draw(t, "elems"))))
+		}
+		want := true
+		for i := range sets {
+			for j := i + 1; j < len(sets); j++ {
+				want = want && Disjoint(sets[i], sets[j])
+			}
+		}
+		if got := DisjointAll(sets...); got != want {
+			t.Fatalf("DisjointAll = %v, pairwise Disjoint = %v", got, want)
+		}
+	})
+}
+
+func TestValidatePartition(t *testing.T) {
+	universe := NewWith(1, 2, 3, 4)
+	tests := []struct {
+		name    string
+		parts   []Set[int]
+		wantErr string
+	}{
+		{"valid", []Set[int]{NewWith(1, 3), NewOrderedWith(2, 4)}, ""},
+		{"valid with empty part", []Set[int]{NewWith(1, 2, 3, 4), nil, New[int]()}, ""},
+		{"overlap", []Set[int]{NewWith(1, 2), NewWith(2, 3, 4)}, "invalid partition: element 2 is in part 0 and part 1"},
+		{"outside universe", []Set[int]{NewWith(1, 2, 3, 4), NewWith(9)}, "invalid partition: element 9 of part 1 is not in the universe"},
+		{"gap", []Set[int]{NewWith(1, 2, 3)}, "invalid partition: 1 element(s) of the universe are in no part, including 4"},
+		{"no parts", nil, "invalid partition: 4 element(s) of the universe are in no part"},
+	}
+	for _, tt := range tests {
+		err := ValidatePartition[int](universe, tt.parts...)
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+		case tt.wantErr != "" && (err == nil || !strings.HasPrefix(err.Error(), tt.wantErr)):
+			t.Errorf("%s: error = %v, want %q", tt.name, err, tt.wantErr)
+		}
+		if got := IsPartition[int](universe, tt.parts...); got != (err == nil) {
+			t.Errorf("%s: IsPartition = %v, ValidatePartition error = %v", tt.name, got, err)
+		}
+	}
+	if !IsPartition[int](nil) || ValidatePartition[int](nil, New[int]()) != nil {
+		t.Error("an empty universe should be partitioned by no parts or empty parts")
+	}
+}
+
+func TestSyncMapSnapshot(t *testing.T) {
+	var nilSet *SyncMap[int]
+	if got := nilSet.Snapshot(); got != nil {
+		t.Fatalf("nil.Snapshot() = %v, want nil", got)
+	}
+	if got := NewSyncMap[int]().Snapshot(); got != nil {
+		t.Fatalf("empty.Snapshot() = %v, want nil", got)
+	}
+	got := NewSyncMapWith(3, 1, 2).Snapshot()
+	slices.Sort(got)
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Fatalf("Snapshot() = %v, want [1 2 3]", got)
+	}
+}
+
+func TestConcat(t *testing.T) {
+	if got := Concat[int](); got.Cardinality() != 0 {
+		t.Fatalf("Concat() = %v, want empty", got)
+	}
+	if _, ok := Concat(nil, NewSortedSetWith(2, 1)).(*Ordered[int]); !ok {
+		t.Fatal("Concat with a nil first set should return an *Ordered")
+	}
+	if got := Elements(Concat[int](NewSortedSetWith(3), NewOrderedWith(2, 1))); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Fatalf("Concat into a SortedSet = %v, want [1 2 3]", got)
+	}
+
+	rapid.Check(t, func(t *rapid.T) {
+		var sets []OrderedSet[int]
+		var want []int
+		for range rapid.IntRange(1, 5).Draw(t, "n") {
+			s := NewOrderedFrom(slices.Values(rapid.SliceOfN(rapid.IntRange(0, 20), 0, 6).Draw(t, "elems")))
+			sets = append(sets, s)
+			for k := range s.Iterator {
+				if !slices.Contains(want, k) {
+					want = append(want, k)
+				}
+			}
+		}
+		got := Concat(sets...)
+		if !slices.Equal(Elements(got), want) {
+			t.Fatalf("Concat = %v, want %v", got, want)
+		}
+		if _, ok := got.(*Ordered[int]); !ok {
+			t.Fatalf("Concat returned %T, want *Ordered[int]", got)
+		}
+	})
+}
+
+// TestOrdered_SortedTracking checks that an Ordered set is only ever known to be sorted when it is, and that its Max
+// and Min fast paths agree with iterating.
+func TestOrdered_SortedTracking(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := NewOrdered[int]()
+		steps := rapid.IntRange(1, 100).Draw(t, "Steps")
+		for range steps {
+			v := rapid.IntRange(0, 20).Draw(t, "Value")
+			switch rapid.IntRange(0, 9).Draw(t, "Op") {
+			case 0, 1:
+				s.Add(v)
+			case 2, 3:
+				s.AddSorted(v)
+			case 4:
+				s.Remove(v)
+			case 5:
+				s.Sort()
+			case 6:
+				s.MoveToFront(v)
+			case 7:
+				s.MoveToBack(v)
+			case 8:
+				SortBy(s, func(m int) int { return -m })
+			case 9:
+				s = s.Clone().(*Ordered[int])
+			}
+			if s.sorted && !IsSorted[int](s) {
+				t.Fatalf("set %v is marked sorted", s)
+			}
+			if m, ok := s.Max(); ok && m != slices.Max(Elements[int](s)) {
+				t.Fatalf("Max() = %d for %v", m, s)
+			}
+			if m, ok := s.Min(); ok && m != slices.Min(Elements[int](s)) {
+				t.Fatalf("Min() = %d for %v", m, s)
+			}
+		}
+	})
+
+	s := NewOrderedWith(3, 1, 2)
+	if _, ok := s.Max(); ok {
+		t.Fatal("Max() handled an unsorted set")
+	}
+	s.Sort()
+	s.Add(4)
+	s.Remove(1)
+	if m, ok := s.Max(); !ok || m != 4 {
+		t.Fatalf("Max() = %d, %v, want 4, true", m, ok)
+	}
+	if m, ok := s.Min(); !ok || m != 2 {
+		t.Fatalf("Min() = %d, %v, want 2, true", m, ok)
+	}
+}
+
+func TestOrdered_SortedFlagTransitions(t *testing.T) {
+	s := NewOrdered[int]()
+	steps := []struct {
+		name string
+		op   func()
+		want bool
+	}{
+		{"new", func() {}, true},
+		{"in-order adds", func() { AppendSeq[int](s, slices.Values([]int{1, 3, 5})) }, true},
+		{"re-add", func() { s.Add(1) }, true},
+		{"remove", func() { s.Remove(3) }, true},
+		{"remove last then add smaller", func() { s.Remove(5); s.Add(4) }, true},
+		{"out-of-order add", func() { s.Add(2) }, false},
+		{"sort", func() { s.Sort() }, true},
+		{"add sorted", func() { s.AddSorted(3) }, true},
+		{"move to back", func() { s.MoveToBack(1) }, false},
+		{"clear", func() { s.Clear() }, true},
+		{"move to front", func() { AppendSeq[int](s, slices.Values([]int{1, 2})); s.MoveToFront(2) }, false},
+		{"sort by", func() { s.Sort(); SortBy(s, func(m int) int { return m }) }, false},
+		{"swap", func() { s.Sort(); s.Swap(0, 1) }, false},
+		{"sort stable", func() { s.SortStable() }, true},
+		{"pop keeps order", func() { s.Sort(); s.Pop() }, true},
+	}
+	for _, step := range steps {
+		step.op()
+		if s.sorted != step.want {
+			t.Fatalf("after %s: sorted = %v, want %v (set %v)", step.name, s.sorted, step.want, s)
+		}
+		if step.want && !IsSorted[int](s) {
+			t.Fatalf("after %s: IsSorted = false for %v", step.name, s)
+		}
+	}
+
+	// an unknown but sorted set still reports sorted via the scan, through every wrapper
+	s = NewOrderedWith(1, 3, 2)
+	s.Remove(3)
+	b := NewBounded[int](5)
+	b.Add(2)
+	b.Add(1)
+	b.Remove(2)
+	for _, os := range []OrderedSet[int]{s, NewLockedOrderedWrapping[int](s), b} {
+		if !IsSorted(os) {
+			t.Fatalf("IsSorted(%v) = false", os)
+		}
+	}
+}
+
+func TestEstimateIntersection(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	if got := EstimateIntersection[int](nil, NewWith(1), 10, r); got != 0 {
+		t.Fatalf("estimate with an empty set = %v, want 0", got)
+	}
+	if got := EstimateIntersection[int](NewWith(1, 2, 3), NewWith(2, 3, 4, 5), 3, r); got != 2 {
+		t.Fatalf("estimate with sampleSize >= n = %v, want the exact 2", got)
+	}
+
+	// 20,000 elements overlapping on 5,000: with 2,000 samples the standard error is at most 20000/(2*sqrt(2000)) ≈ 224
+	const n, overlap, samples = 20_000, 5_000, 2_000
+	small := genInts(n)
+	large := slices.Collect(func(yield func(int) bool) {
+		for i := n - overlap; i < 3*n; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	})
+	for _, tc := range []struct {
+		name string
+		a, b Set[int]
+	}{
+		{"unordered", NewWith(small...), NewWith(large...)},
+		{"indexed", NewOrderedWith(small...), NewWith(large...)},
+		{"larger first", NewWith(large...), NewOrderedWith(small...)},
+	} {
+		got := EstimateIntersection(tc.a, tc.b, samples, r)
+		if math.Abs(got-overlap) > 4*224 {
+			t.Errorf("%s: estimate %v, want %d ± %d", tc.name, got, overlap, 4*224)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic for sampleSize 0")
+		}
+	}()
+	EstimateIntersection[int](New[int](), New[int](), 0, nil)
+}
+
+func TestPrettyPrint(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"empty", PrettyPrint[int](New[int](), 3), ""},
+		{"nil", PrettyPrint[int](nil, 3), ""},
+		{"numbers right-aligned", PrettyPrint[int](NewWith(100, 9, -5, 42, 7), 3), "-5    7  9\n42  100"},
+		{"one row", PrettyPrint[int](NewWith(2, 1), 5), "1  2"},
+		{"strings left-aligned", PrettyPrint[string](NewWith("gamma", "ab", "beta", "épée", "c"), 2),
+			"ab    beta\nc     gamma\népée"},
+		{"multi-line escaped", PrettyPrint[string](NewWith("a\nb", "tab\t", "z"), 3), `"a\nb"  "tab\t"  z`},
+	} {
+		if tc.got != tc.want {
+			t.Errorf("%s: PrettyPrint() = %q, want %q", tc.name, tc.got, tc.want)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic for perLine 0")
+		}
+	}()
+	PrettyPrint[int](NewWith(1), 0)
+}
+
+func TestMap_Cap(t *testing.T) {
+	t.Parallel()
+
+	var nilMap *Map[int]
+	if c := nilMap.Cap(); c != 0 {
+		t.Fatalf("nil Cap() = %d, want 0", c)
+	}
+	s := NewWith(1, 2, 3)
+	if c := s.Cap(); c != 3 {
+		t.Fatalf("NewWith(1, 2, 3).Cap() = %d, want 3", c)
+	}
+	AppendSeq[int](s, slices.Values(genInts(10)))
+	s.Remove(9)
+	s.Clear()
+	if c := s.Cap(); c != 10 {
+		t.Fatalf("Cap() after growing to 10 and clearing = %d, want the high-water mark 10", c)
+	}
+	s.Grow(5)
+	if c := s.Cap(); c != 10 {
+		t.Fatalf("Cap() after Grow within capacity = %d, want 10", c)
+	}
+	s.Add(1)
+	s.Grow(20)
+	if c := s.Cap(); c != 21 {
+		t.Fatalf("Cap() after Grow(20) with 1 element = %d, want 21", c)
+	}
+	if c := s.Clone().(*Map[int]).Cap(); c != 1 {
+		t.Fatalf("Clone().Cap() = %d, want 1", c)
+	}
+}
+
+func TestNewReservoir(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	if got := NewReservoir(slices.Values([]int{3, 1, 3, 2, 1}), 5, r); !Equal[int](got, NewWith(1, 2, 3)) {
+		t.Fatalf("NewReservoir with fewer than k distinct elements = %v, want all of them", got)
+	}
+	if got := NewReservoir(slices.Values([]int(nil)), 1, nil); got.Cardinality() != 0 {
+		t.Fatalf("NewReservoir of an empty sequence = %v, want empty", got)
+	}
+
+	// 10 distinct elements, 0 repeated 1,000 times: each should still be kept with probability k/10 = 0.3, so in 4,000
+	// trials about 1,200 times with a standard deviation of sqrt(4000*0.3*0.7) ≈ 29
+	const k, trials = 3, 4_000
+	stream := slices.Repeat([]int{0}, 1_000)
+	stream = append(stream, genInts(10)...)
+	counts := make(map[int]int)
+	for range trials {
+		got := NewReservoir(slices.Values(stream), k, r)
+		if got.Cardinality() != k {
+			t.Fatalf("NewReservoir() = %v, want %d elements", got, k)
+		}
+		for m := range got.Iterator {
+			counts[m]++
+		}
+	}
+	for m := range 10 {
+		if math.Abs(float64(counts[m])-trials*k/10) > 5*29 {
+			t.Errorf("element %d kept %d times, want %d ± %d", m, counts[m], trials*k/10, 5*29)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic for k 0")
+		}
+	}()
+	NewReservoir(slices.Values([]int{1}), 0, nil)
+}
+
+// TestNewReservoir_Reproducible checks that two *rand.Rand seeded alike draw the same sample, and that other seeds
+// draw other samples.
+func TestNewReservoir_Reproducible(t *testing.T) {
+	t.Parallel()
+
+	stream := genInts(1_000)
+	sample := func(seed uint64) *Map[int] {
+		return NewReservoir(slices.Values(stream), 10, rand.New(rand.NewPCG(seed, 0)))
+	}
+	distinct := 0
+	for seed := range uint64(20) {
+		a, b := sample(seed), sample(seed)
+		if !Equal[int](a, b) {
+			t.Fatalf("seed %d: samples %v and %v differ", seed, a, b)
+		}
+		if !Equal[int](a, sample(seed+100)) {
+			distinct++
+		}
+	}
+	if distinct == 0 {
+		t.Fatal("every seed drew the same sample")
+	}
+}
+
+// TestAtMany checks AtMany against At for each index, including negative and out-of-bounds ones, over an Ordered with
+// gaps left by removals and a LockedOrdered wrapping it.
+func TestAtMany(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := NewOrderedWith(rapid.SliceOfN(rapid.IntRange(1, 30), 0, 20).Draw(t, "elements")...)
+		RemoveSeq[int](s, slices.Values(rapid.SliceOfN(rapid.IntRange(1, 30), 0, 5).Draw(t, "removed")))
+		indices := rapid.SliceOfN(rapid.IntRange(-25, 25), 0, 10).Draw(t, "indices")
+		want := make([]int, len(indices))
+		for i, j := range indices {
+			want[i], _ = s.At(j) // 0 when out of bounds, which is never an element
+		}
+		if got := s.AtMany(indices...); !slices.Equal(got, want) {
+			t.Fatalf("%v.AtMany(%v) = %v, want %v", s, indices, got, want)
+		}
+		l := NewLockedOrderedWrapping[int](s).(*LockedOrdered[int])
+		if got := l.AtMany(indices...); !slices.Equal(got, want) {
+			t.Fatalf("LockedOrdered %v.AtMany(%v) = %v, want %v", s, indices, got, want)
+		}
+	})
+}
+
+// TestHeadTail checks Head and Tail against slicing the elements, with n clamped, on an Ordered with removal holes and
+// on a LockedOrdered wrapping a copy of it, and that the source is unchanged.
+func TestHeadTail(t *testing.T) {
+	type headTailer interface {
+		OrderedSet[int]
+		Head(n int) OrderedSet[int]
+		Tail(n int) OrderedSet[int]
+	}
+	rapid.Check(t, func(t *rapid.T) {
+		s := NewOrderedWith(rapid.SliceOfN(rapid.IntRange(1, 30), 0, 20).Draw(t, "elements")...)
+		RemoveSeq[int](s, slices.Values(rapid.SliceOfN(rapid.IntRange(1, 30), 0, 5).Draw(t, "removed")))
+		n := rapid.IntRange(-3, 25).Draw(t, "n")
+		want := Elements[int](s)
+		k := min(max(n, 0), len(want))
+
+		for _, o := range []headTailer{s, NewLockedOrderedWrapping[int](s.Clone().(OrderedSet[int])).(*LockedOrdered[int])} {
+			if got := Elements[int](o.Head(n)); !slices.Equal(got, want[:k]) {
+				t.Fatalf("%T.Head(%d) of %v = %v, want %v", o, n, want, got, want[:k])
+			}
+			if got := Elements[int](o.Tail(n)); !slices.Equal(got, want[len(want)-k:]) {
+				t.Fatalf("%T.Tail(%d) of %v = %v, want %v", o, n, want, got, want[len(want)-k:])
+			}
+			if got := Elements[int](o); !slices.Equal(got, want) {
+				t.Fatalf("%T: source changed to %v, want %v", o, got, want)
+			}
+		}
+	})
+
+	sorted := NewLockedOrderedWrapping[int](NewSortedSetWith(5, 1, 3)).(*LockedOrdered[int])
+	if h, ok := sorted.Head(2).(*LockedOrdered[int]); !ok || !EqualOrdered[int](h, NewSortedSetWith(1, 3)) {
+		t.Fatalf("Head(2) of a locked SortedSet = %v, want a LockedOrdered of [1 3]", sorted.Head(2))
+	}
+	if got := Elements(sorted.Tail(1)); !slices.Equal(got, []int{5}) {
+		t.Fatalf("Tail(1) of a locked SortedSet = %v, want [5]", got)
+	}
+}
+
+func TestLockedOrdered_Rotate(t *testing.T) {
+	s := NewLockedOrderedWith(1, 2, 3, 4)
+	s.Rotate(-1)
+	if got := Elements[int](s); !slices.Equal(got, []int{4, 1, 2, 3}) {
+		t.Fatalf("Rotate(-1) = %v, want [4 1 2 3]", got)
+	}
+	s.Rotate(9)
+	if got := Elements[int](s); !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Fatalf("Rotate(9) = %v, want [1 2 3 4]", got)
+	}
+
+	sorted := NewLockedOrderedWrapping[int](NewSortedSetWith(1, 2, 3)).(*LockedOrdered[int])
+	sorted.Rotate(1)
+	if got := Elements[int](sorted); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Fatalf("Rotate on a wrapped SortedSet changed its order to %v", got)
+	}
+}
+
+func TestEqualExcept(t *testing.T) {
+	if !EqualExcept[int](nil, New[int](), nil) {
+		t.Fatal("nil and empty sets should be equal")
+	}
+	rapid.Check(t, func(t *rapid.T) {
+		gen := rapid.SliceOfN(rapid.IntRange(0, 10), 0, 8)
+		a, b, ignore := NewWith(gen.Draw(t, "a")...), NewOrderedWith(gen.Draw(t, "b")...), NewWith(gen.Draw(t, "ignore")...)
+		want := Equal(Difference[int](a, ignore), Difference[int](b, ignore))
+		if got := EqualExcept[int](a, b, ignore); got != want {
+			t.Fatalf("EqualExcept(%v, %v, %v) = %v, want %v", a, b, ignore, got, want)
+		}
+		if got := EqualExcept[int](b, a, ignore); got != want {
+			t.Fatalf("EqualExcept(%v, %v, %v) = %v, want %v", b, a, ignore, got, want)
+		}
+	})
+}
+
+func TestOrdered_AtNegativeEmpty(t *testing.T) {
+	if _, ok := NewOrdered[int]().At(-1); ok {
+		t.Fatal("At(-1) on an empty set: expected ok=false")
+	}
+	if v, ok := NewBounded[int](3).At(-1); ok {
+		t.Fatalf("At(-1) on an empty Bounded set = %d, expected ok=false", v)
+	}
+	b := NewBounded[int](2)
+	AppendSeq[int](b, slices.Values([]int{1, 2, 3}))
+	if v, ok := b.At(-1); !ok || v != 3 {
+		t.Fatalf("Bounded.At(-1) = (%d, %v), want (3, true)", v, ok)
+	}
+}
+
+func TestContainsEach(t *testing.T) {
+	items := []int{4, 1, 9, 4, 2, 7}
+	want := []bool{true, true, false, true, true, false}
+	none := make([]bool, len(items))
+	for _, tc := range []struct {
+		s    Set[int]
+		want []bool
+	}{
+		{nil, none},
+		{new(Locked[int]), none},
+		{NewWith(1, 2, 3, 4), want},
+		{NewOrderedWith(1, 2, 3, 4), want},
+		{NewLockedWith(1, 2, 3, 4), want},
+		{NewLockedOrderedWith(1, 2, 3, 4), want},
+	} {
+		if got := ContainsEach(tc.s, items); !slices.Equal(got, tc.want) {
+			t.Fatalf("ContainsEach(%T, %v) = %v, want %v", tc.s, items, got, tc.want)
+		}
+	}
+	if got := ContainsEach[int](NewWith(1), nil); len(got) != 0 {
+		t.Fatalf("ContainsEach with no items = %v, want empty", got)
+	}
+}
+
+func TestComplement(t *testing.T) {
+	universe := NewOrderedWith(1, 2, 3, 4)
+	got := Complement[int](universe, NewWith(2, 4, 9))
+	if _, ok := got.(*Ordered[int]); !ok || !slices.Equal(Elements(got), []int{1, 3}) {
+		t.Fatalf("Complement = %v (%T), want ordered [1 3]", got, got)
+	}
+
+	got, err := ComplementStrict[int](universe, NewWith(2, 4))
+	if err != nil || !slices.Equal(Elements(got), []int{1, 3}) {
+		t.Fatalf("ComplementStrict = %v, %v, want [1 3], nil", got, err)
+	}
+	if got, err := ComplementStrict[int](universe, nil); err != nil || !Equal[int](got, universe) {
+		t.Fatalf("ComplementStrict(universe, nil) = %v, %v, want the universe", got, err)
+	}
+	if got, err := ComplementStrict[int](universe, NewWith(2, 9)); err == nil || got != nil {
+		t.Fatalf("ComplementStrict with an extra element = %v, %v, want an error", got, err)
+	}
+}
+
+// intSetConstructors builds each set type from the given elements, for tests that pair up every set type.
+var intSetConstructors = map[string]func(...int) Set[int]{
+	"Map":           func(m ...int) Set[int] { return NewWith(m...) },
+	"SyncMap":       func(m ...int) Set[int] { return NewSyncMapWith(m...) },
+	"Locked":        func(m ...int) Set[int] { return NewLockedWith(m...) },
+	"Ordered":       func(m ...int) Set[int] { return NewOrderedWith(m...) },
+	"LockedOrdered": func(m ...int) Set[int] { return NewLockedOrderedWith(m...) },
+	"SortedSet":     func(m ...int) Set[int] { return NewSortedSetWith(m...) },
+	"BitSet":        func(m ...int) Set[int] { return NewBitSetWith(m...) },
+	"Bag":           func(m ...int) Set[int] { return NewBagWith(m...) },
+	"SmallSet":      func(m ...int) Set[int] { return NewSmallSetWith(m...) },
+	"Hybrid": func(m ...int) Set[int] {
+		s := NewHybridThreshold[int](4) // low, so that pairings see both backings
+		AppendSeq[int](s, slices.Values(m))
+		return s
+	},
+	"Bounded": func(m ...int) Set[int] {
+		b := NewBounded[int](100)
+		AppendSeq[int](b, slices.Values(m))
+		return b
+	},
+	"Frozen": func(m ...int) Set[int] { return NewBuilder[int]().Add(m...).Build() },
+	"PrioritySet": func(m ...int) Set[int] {
+		s := NewPrioritySet[int]()
+		for _, v := range m {
+			s.AddWithPriority(v, float64(v%5))
+		}
+		return s
+	},
+}
+
+// TestUnionPreferOrdered checks, for every pairing of set types, that UnionPreferOrdered holds the same elements as
+// Union and is ordered whenever either operand is, taking the ordered operand's type and keeping its order.
+func TestUnionPreferOrdered(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		as := rapid.SliceOfN(rapid.IntRange(0, 12), 0, 8).Draw(t, "a")
+		bs := rapid.SliceOfN(rapid.IntRange(0, 12), 0, 8).Draw(t, "b")
+		for an, newA := range intSetConstructors {
+			for bn, newB := range intSetConstructors {
+				a, b := newA(as...), newB(bs...)
+				got := UnionPreferOrdered(a, b)
+				if !Equal(got, Union(a, b)) {
+					t.Fatalf("UnionPreferOrdered(%s%v, %s%v) = %v, want the elements of both", an, as, bn, bs, got)
+				}
+				_, aOrdered := a.(OrderedSet[int])
+				_, bOrdered := b.(OrderedSet[int])
+				if _, ok := got.(OrderedSet[int]); ok != (aOrdered || bOrdered) {
+					t.Fatalf("UnionPreferOrdered(%s, %s) = %T, ordered = %v", an, bn, got, ok)
+				}
+				if !aOrdered && !bOrdered {
+					continue
+				}
+				first := a
+				if !aOrdered {
+					first = b
+				}
+				if reflect.TypeOf(got) != reflect.TypeOf(first.NewEmpty()) {
+					t.Fatalf("UnionPreferOrdered(%s, %s) = %T, want %T", an, bn, got, first.NewEmpty())
+				}
+				if _, ok := first.(*Bounded[int]); ok {
+					continue // re-adding refreshes a Bounded element, moving it to the back
+				}
+				// the ordered operand's elements keep their relative order
+				kept := slices.DeleteFunc(Elements(got), func(k int) bool { return !first.Contains(k) })
+				if want := Elements(first); !slices.Equal(kept, want) {
+					t.Fatalf("UnionPreferOrdered(%s%v, %s%v) = %v, want %v in order", an, as, bn, bs, got, want)
+				}
+			}
+		}
+	})
+}
+
+func TestLen(t *testing.T) {
+	if n := Len[int](nil); n != 0 {
+		t.Fatalf("Len(nil) = %d, want 0", n)
+	}
+	for name, newSet := range intSetConstructors {
+		if s := newSet(3, 1, 2, 1); Len(s) != s.Cardinality() || Len(s) != 3 {
+			t.Fatalf("Len(%s%v) = %d, want 3", name, s, Len(s))
+		}
+	}
+}
+
+// TestMissingPresent checks, for every set type, that Missing and Present split the candidates by membership into sets
+// of the type of s.NewEmpty().
+func TestMissingPresent(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		ss := rapid.SliceOfN(rapid.IntRange(0, 12), 0, 8).Draw(t, "s")
+		cs := rapid.SliceOfN(rapid.IntRange(0, 12), 0, 8).Draw(t, "candidates")
+		for name, newSet := range intSetConstructors {
+			s := newSet(ss...)
+			missing, present := Missing(s, slices.Values(cs)), Present(s, slices.Values(cs))
+			if !Equal(missing, Difference[int](NewWith(cs...), s)) || !Equal(present, Intersection[int](NewWith(cs...), s)) {
+				t.Fatalf("%s: Missing = %v, Present = %v for candidates %v", name, missing, present, cs)
+			}
+			if reflect.TypeOf(missing) != reflect.TypeOf(s.NewEmpty()) || reflect.TypeOf(present) != reflect.TypeOf(s.NewEmpty()) {
+				t.Fatalf("%s: results are %T and %T, want %T", name, missing, present, s.NewEmpty())
+			}
+		}
+	})
+	if got := Missing[int](nil, slices.Values([]int{1})); !Equal(got, NewWith(1)) {
+		t.Fatalf("Missing(nil, [1]) = %v, want [1]", got)
+	}
+}
+
+// TestPopMinMax checks, for every mutable set type, that popping with PopMin yields the elements in ascending order and
+// PopMax in descending order, then reports an empty set.
+func TestPopMinMax(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		elems := rapid.SliceOfNDistinct(rapid.IntRange(-20, 20), 0, 10, rapid.ID[int]).Draw(t, "elements")
+		asc := slices.Sorted(slices.Values(elems))
+		desc := slices.Clone(asc)
+		slices.Reverse(desc)
+		for name, newSet := range intSetConstructors {
+			if name == "Frozen" {
+				continue // read-only: Remove is a no-op
+			}
+			for _, tc := range []struct {
+				pop  func(Set[int]) (int, bool)
+				want []int
+			}{{PopMin[int], asc}, {PopMax[int], desc}} {
+				s := newSet(elems...)
+				var got []int
+				for v, ok := tc.pop(s); ok; v, ok = tc.pop(s) {
+					got = append(got, v)
+				}
+				if !slices.Equal(got, tc.want) || s.Cardinality() != 0 {
+					t.Fatalf("%s: popped %v, leaving %v, want %v", name, got, s, tc.want)
+				}
+			}
+		}
+	})
+	if _, ok := PopMax[int](nil); ok {
+		t.Fatal("PopMax(nil) reported an element")
+	}
+	if _, ok := PopMin(NewWith(math.NaN())); ok {
+		t.Fatal("PopMin reported removing a NaN")
+	}
+}
+
+// TestStats checks, for every set type, that Stats agrees with Min, Max and Cardinality.
+func TestStats(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		elems := rapid.SliceOfN(rapid.IntRange(-20, 20), 0, 10).Draw(t, "elements")
+		for name, newSet := range intSetConstructors {
+			s := newSet(elems...)
+			mn, mx, n, ok := Stats(s)
+			if ok != (s.Cardinality() > 0) || n != s.Cardinality() {
+				t.Fatalf("%s: Stats(%v) = count %d, ok %v", name, s, n, ok)
+			}
+			if ok && (mn != Min(s) || mx != Max(s)) {
+				t.Fatalf("%s: Stats(%v) = min %d, max %d, want %d, %d", name, s, mn, mx, Min(s), Max(s))
+			}
+		}
+	})
+	if mn, mx, n, ok := Stats[int](nil); mn != 0 || mx != 0 || n != 0 || ok {
+		t.Fatalf("Stats(nil) = %d, %d, %d, %v", mn, mx, n, ok)
+	}
+}
+
+// TestEqualSlice checks, for every set type, that EqualSlice agrees with Equal against a Map of the slice, and, for the
+// ordered types, that EqualSliceOrdered agrees with EqualOrdered against an Ordered of the slice.
+func TestEqualSlice(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		elems := rapid.SliceOfN(rapid.IntRange(0, 6), 0, 6).Draw(t, "elements")
+		items := rapid.SliceOfN(rapid.IntRange(0, 6), 0, 8).Draw(t, "items")
+		if rapid.Bool().Draw(t, "same") {
+			items = rapid.Permutation(elems).Draw(t, "permutation")
+		}
+		for name, newSet := range intSetConstructors {
+			s := newSet(elems...)
+			if got, want := EqualSlice(s, items), Equal[int](s, NewWith(items...)); got != want {
+				t.Fatalf("%s: EqualSlice(%v, %v) = %v, want %v", name, s, items, got, want)
+			}
+			if o, ok := s.(OrderedSet[int]); ok {
+				if got, want := EqualSliceOrdered(o, items), EqualOrdered[int](o, NewOrderedWith(items...)); got != want {
+					t.Fatalf("%s: EqualSliceOrdered(%v, %v) = %v, want %v", name, s, items, got, want)
+				}
+			}
+		}
+	})
+	if !EqualSlice[int](nil, nil) || EqualSlice[int](nil, []int{1}) {
+		t.Fatal("EqualSlice does not treat a nil set as empty")
+	}
+	for _, s := range []OrderedSet[int]{nil, (*Ordered[int])(nil), (*LockedOrdered[int])(nil), NewOrdered[int]()} {
+		if !EqualSliceOrdered(s, nil) || EqualSliceOrdered(s, []int{1}) {
+			t.Fatalf("EqualSliceOrdered does not treat a nil or empty %T as empty", s)
+		}
+	}
+}
+
+// TestHash checks that Equal sets hash the same whatever their types and insertion orders, and that sets that differ
+// (here, in at most a few elements) hash differently.
+func TestHash(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		as := rapid.SliceOfN(rapid.IntRange(-12, 12), 0, 10).Draw(t, "a")
+		bs := rapid.Permutation(as).Draw(t, "b")
+		if rapid.Bool().Draw(t, "different") {
+			bs = append(bs, rapid.IntRange(-12, 12).Draw(t, "extra"))
+		}
+		want := Equal[int](NewWith(as...), NewWith(bs...))
+		for an, a := range intSetConstructors {
+			for bn, b := range intSetConstructors {
+				if got := Hash(a(as...)) == Hash(b(bs...)); got != want {
+					t.Fatalf("Hash(%s%v) == Hash(%s%v) is %v, want %v", an, as, bn, bs, got, want)
+				}
+			}
+		}
+	})
+
+	if Hash[int](nil) != Hash[int](New[int]()) {
+		t.Fatal("a nil set and an empty set hash differently")
+	}
+	if Hash[float64](NewWith(math.Copysign(0, -1))) != Hash[float64](NewWith(0.0)) {
+		t.Fatal("sets holding -0 and +0 hash differently")
+	}
+	if Hash[string](NewWith("ab", "c")) == Hash[string](NewWith("a", "bc")) {
+		t.Fatal("string element boundaries do not affect the hash")
+	}
+	if Hash[int](NewWith(0)) == Hash[int](New[int]()) {
+		t.Fatal("the set of the zero value hashes as the empty set")
+	}
+
+	type point struct{ x, y int }
+	byFields := func(p point) uint64 { return uint64(p.x)<<32 | uint64(uint32(p.y)) }
+	if HashFunc(NewWith(point{1, 2}, point{3, 4}), byFields) != HashFunc(NewWith(point{3, 4}, point{1, 2}), byFields) {
+		t.Fatal("HashFunc depends on insertion order")
+	}
+}
+
+func TestEqualHashed(t *testing.T) {
+	t.Parallel()
+
+	a, b, c := NewWith(1, 2, 3), NewOrderedWith(3, 2, 1), NewWith(1, 2, 4)
+	ha, hb, hc := Hash[int](a), Hash[int](b), Hash[int](c)
+	if !EqualHashed[int](a, ha, b, hb) || EqualHashed[int](a, ha, c, hc) {
+		t.Fatal("EqualHashed disagrees with Equal given current hashes")
+	}
+	// matching hashes are only a hint: the elements still decide
+	if EqualHashed[int](a, ha, c, ha) {
+		t.Fatal("EqualHashed trusted a hash collision")
+	}
+	// different hashes reject without looking at the elements
+	if EqualHashed[int](a, ha, b, hb+1) {
+		t.Fatal("EqualHashed compared elements despite different hashes")
+	}
+}
+
+// TestEqualMixedTypes compares every pairing of the package's set types, ordered and unordered, holding the same or
+// different elements in different orders. Equal is order-insensitive whatever its argument types.
+func TestEqualMixedTypes(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		as := rapid.SliceOfN(rapid.IntRange(0, 12), 0, 8).Draw(t, "a")
+		bs := rapid.SliceOfN(rapid.IntRange(0, 12), 0, 8).Draw(t, "b")
+		if rapid.Bool().Draw(t, "same elements") {
+			bs = slices.Clone(as)
+			slices.Reverse(bs)
+		}
+		want := Equal[int](NewWith(as...), NewWith(bs...))
+		for an, a := range intSetConstructors {
+			for bn, b := range intSetConstructors {
+				if got := Equal(a(as...), b(bs...)); got != want {
+					t.Fatalf("Equal(%s%v, %s%v) = %v, want %v", an, as, bn, bs, got, want)
+				}
+			}
+		}
+	})
+}
+
+// TestTransform checks Transform against applying the function to the elements in order and keeping the first of each
+// result, and that the rebuilt set is fully usable.
+func TestTransform(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		in := rapid.SliceOf(rapid.IntRange(-20, 20)).Draw(t, "in")
+		div := rapid.IntRange(1, 5).Draw(t, "div")
+		f := func(v int) int { return v / div }
+
+		s := NewOrderedWith(in...)
+		s.Remove(rapid.IntRange(-20, 20).Draw(t, "removed")) // leave a gap in the backing storage
+		var want []int
+		for v := range s.Iterator {
+			if v = f(v); !slices.Contains(want, v) {
+				want = append(want, v)
+			}
+		}
+
+		Transform(s, f)
+		if got := slices.Collect(s.Iterator); !slices.Equal(got, want) {
+			t.Fatalf("Transform yielded %v, want %v", got, want)
+		}
+		for i, v := range want {
+			if got := s.Index(v); got != i {
+				t.Fatalf("Index(%d) = %d, want %d", v, got, i)
+			}
+		}
+		if got, want := IsSorted[int](s), slices.IsSorted(want); got != want {
+			t.Fatalf("IsSorted() = %t, want %t", got, want)
+		}
+		if !s.Add(100) || s.Index(100) != len(want) {
+			t.Fatalf("Add after Transform did not append: %v", s)
+		}
+	})
+
+	// NaN sorts before every number, as cmp.Less orders it, so [1 NaN] is not sorted
+	f := NewOrderedWith(1.0, 2.0)
+	Transform(f, func(v float64) float64 {
+		if v == 2 {
+			return math.NaN()
+		}
+		return v
+	})
+	if IsSorted[float64](f) || IsSorted[float64](NewOrderedWith(1, math.NaN())) {
+		t.Fatalf("IsSorted(%v) = true", f)
+	}
+}
+
+// TestLocked_Checkpoint checks that the restore function returned by Checkpoint reverts every kind of change, can be
+// called more than once, and keeps the order of a LockedOrdered set.
+func TestLocked_Checkpoint(t *testing.T) {
+	locked := NewLockedWith(1, 2, 3)
+	restore := locked.Checkpoint()
+	locked.Remove(1)
+	locked.AddAll(4, 5)
+	restore()
+	if want := NewWith(1, 2, 3); !Equal[int](locked, want) {
+		t.Fatalf("after restore: %v, want %v", locked, want)
+	}
+	locked.Clear()
+	restore()
+	if got := locked.Cardinality(); got != 3 {
+		t.Fatalf("after second restore: Cardinality() = %d, want 3", got)
+	}
+
+	ordered := NewLockedOrderedWith(3, 1, 2)
+	restore = ordered.Checkpoint()
+	ordered.Sort()
+	ordered.Add(0)
+	restore()
+	if got, want := Elements[int](ordered), []int{3, 1, 2}; !slices.Equal(got, want) {
+		t.Fatalf("after restore: %v, want %v", got, want)
+	}
+
+	empty := new(Locked[int])
+	restore = empty.Checkpoint()
+	empty.Add(1)
+	restore()
+	if got := empty.Cardinality(); got != 0 {
+		t.Fatalf("zero value after restore: Cardinality() = %d, want 0", got)
+	}
+}
+
+// TestMerge checks each set type's Merge against Union, with the other set of every type, and that ordered receivers
+// append the new elements in the other set's order.
+func TestMerge(t *testing.T) {
+	type merger interface {
+		Set[int]
+		Merge(Set[int]) int
+	}
+	rapid.Check(t, func(t *rapid.T) {
+		as := rapid.SliceOfN(rapid.IntRange(0, 12), 0, 8).Draw(t, "a")
+		bs := rapid.SliceOfN(rapid.IntRange(0, 12), 0, 8).Draw(t, "b")
+		want := Union[int](NewWith(as...), NewWith(bs...))
+		for an, a := range intSetConstructors {
+			for bn, b := range intSetConstructors {
+				s, ok := a(as...).(merger)
+				if !ok {
+					continue // Frozen is read-only
+				}
+				other := b(bs...)
+				if got, want := s.Merge(other), want.Cardinality()-len(Elements(NewWith(as...))); got != want {
+					t.Fatalf("%s%v.Merge(%s%v) = %d, want %d", an, as, bn, bs, got, want)
+				}
+				if !Equal[int](s, want) {
+					t.Fatalf("%s%v.Merge(%s%v) left %v, want %v", an, as, bn, bs, s, want)
+				}
+				if _, ordered := other.(interface{ At(int) (int, bool) }); ordered && (an == "Ordered" || an == "LockedOrdered") {
+					wantOrder := Elements[int](NewOrderedWith(as...))
+					for v := range other.Iterator {
+						if !slices.Contains(wantOrder, v) {
+							wantOrder = append(wantOrder, v)
+						}
+					}
+					if got := Elements[int](s); !slices.Equal(got, wantOrder) {
+						t.Fatalf("%s%v.Merge(%s%v) order = %v, want %v", an, as, bn, bs, got, wantOrder)
+					}
+				}
+			}
+			if s, ok := a(as...).(merger); ok {
+				if n := s.Merge(s); n != 0 || !Equal[int](s, NewWith(as...)) {
+					t.Fatalf("%s%v.Merge(itself) = %d, left %v", an, as, n, s)
+				}
+			}
+		}
+	})
+
+	t.Run("Bag sums counts", func(t *testing.T) {
+		b := NewBagWith("a", "a", "b")
+		if n := b.Merge(NewBagWith("a", "c", "c")); n != 1 {
+			t.Fatalf("Merge() = %d, want 1", n)
+		}
+		if b.Count("a") != 3 || b.Count("b") != 1 || b.Count("c") != 2 || b.Total() != 6 {
+			t.Fatalf("Merge() left %v", b)
+		}
+	})
+
+	t.Run("BitSet span", func(t *testing.T) {
+		b := NewBitSetWith(100, 200)
+		if n := b.Merge(NewBitSetWith(-300, 150, 200, 900)); n != 3 {
+			t.Fatalf("Merge() = %d, want 3", n)
+		}
+		if got, want := Elements[int](b), []int{-300, 100, 150, 200, 900}; !slices.Equal(got, want) {
+			t.Fatalf("Merge() left %v, want %v", got, want)
+		}
+	})
+}
+
+// TestRemoveAll checks each set type's RemoveAll(other) against Difference, with the other set of every type, and that
+// ordered receivers keep the order of the remaining elements.
+func TestRemoveAll(t *testing.T) {
+	type setRemover interface {
+		Set[int]
+		RemoveAll(Set[int]) int
+	}
+	removeAll := func(s Set[int], other Set[int]) (int, bool) {
+		if r, ok := s.(setRemover); ok {
+			return r.RemoveAll(other), true
+		}
+		return 0, false // Frozen is read-only
+	}
+	rapid.Check(t, func(t *rapid.T) {
+		as := rapid.SliceOfN(rapid.IntRange(0, 12), 0, 12).Draw(t, "a")
+		bs := rapid.SliceOfN(rapid.IntRange(0, 12), 0, 8).Draw(t, "b")
+		want := Difference[int](NewOrderedWith(as...), NewWith(bs...))
+		for an, a := range intSetConstructors {
+			for bn, b := range intSetConstructors {
+				s := a(as...)
+				n, ok := removeAll(s, b(bs...))
+				if !ok {
+					continue
+				}
+				if wantN := len(Elements(NewWith(as...))) - want.Cardinality(); n != wantN {
+					t.Fatalf("%s%v.RemoveAll(%s%v) = %d, want %d", an, as, bn, bs, n, wantN)
+				}
+				if !Equal[int](s, want) {
+					t.Fatalf("%s%v.RemoveAll(%s%v) left %v, want %v", an, as, bn, bs, s, want)
+				}
+				if an == "Ordered" || an == "LockedOrdered" || an == "Bounded" {
+					wantOrder := Elements(Difference(a(as...), NewWith(bs...)))
+					if got := Elements[int](s); !slices.Equal(got, wantOrder) {
+						t.Fatalf("%s%v.RemoveAll(%s%v) order = %v, want %v", an, as, bn, bs, got, wantOrder)
+					}
+				}
+			}
+			s := a(as...)
+			if r, ok := s.(setRemover); ok {
+				if n := r.RemoveAll(s); n != len(Elements(NewWith(as...))) || s.Cardinality() != 0 {
+					t.Fatalf("%s%v.RemoveAll(itself) = %d, left %v", an, as, n, s)
+				}
+			}
+		}
+	})
+
+	t.Run("Bag removes every occurrence", func(t *testing.T) {
+		b := NewBagWith("a", "a", "b", "c")
+		if n := b.RemoveAll(NewBagWith("a", "c", "d")); n != 2 {
+			t.Fatalf("RemoveAll() = %d, want 2", n)
+		}
+		if b.Count("a") != 0 || b.Count("b") != 1 || b.Total() != 1 {
+			t.Fatalf("RemoveAll() left %v", b)
+		}
+	})
+}
+
+// TestKeep checks each set type's Keep(other) against Intersection, with the other set of every type, and that ordered
+// receivers keep the order of the remaining elements.
+func TestKeep(t *testing.T) {
+	type keeper interface {
+		Set[int]
+		Keep(Set[int]) int
+	}
+	rapid.Check(t, func(t *rapid.T) {
+		as := rapid.SliceOfN(rapid.IntRange(0, 12), 0, 12).Draw(t, "a")
+		bs := rapid.SliceOfN(rapid.IntRange(0, 12), 0, 8).Draw(t, "b")
+		want := Intersection[int](NewWith(as...), NewWith(bs...))
+		for an, a := range intSetConstructors {
+			for bn, b := range intSetConstructors {
+				s := a(as...)
+				k, ok := s.(keeper)
+				if !ok {
+					continue // Frozen is read-only
+				}
+				n := k.Keep(b(bs...))
+				if wantN := len(Elements(NewWith(as...))) - want.Cardinality(); n != wantN {
+					t.Fatalf("%s%v.Keep(%s%v) = %d, want %d", an, as, bn, bs, n, wantN)
+				}
+				if !Equal[int](s, want) {
+					t.Fatalf("%s%v.Keep(%s%v) left %v, want %v", an, as, bn, bs, s, want)
+				}
+				if an == "Ordered" || an == "LockedOrdered" || an == "Bounded" {
+					wantOrder := Elements(Intersection(a(as...), NewWith(bs...)))
+					if got := Elements[int](s); !slices.Equal(got, wantOrder) {
+						t.Fatalf("%s%v.Keep(%s%v) order = %v, want %v", an, as, bn, bs, got, wantOrder)
+					}
+				}
+			}
+			s := a(as...)
+			if k, ok := s.(keeper); ok {
+				if n := k.Keep(s); n != 0 || s.Cardinality() != len(Elements(NewWith(as...))) {
+					t.Fatalf("%s%v.Keep(itself) = %d, left %v", an, as, n, s)
+				}
+				if n := k.Keep(nil); n != len(Elements(NewWith(as...))) || s.Cardinality() != 0 {
+					t.Fatalf("%s%v.Keep(nil) = %d, left %v", an, as, n, s)
+				}
+			}
+		}
+	})
+
+	t.Run("Bag keeps counts", func(t *testing.T) {
+		b := NewBagWith("a", "a", "b", "c")
+		if n := b.Keep(NewWith("a", "c", "d")); n != 1 {
+			t.Fatalf("Keep() = %d, want 1", n)
+		}
+		if b.Count("a") != 2 || b.Count("b") != 0 || b.Total() != 3 {
+			t.Fatalf("Keep() left %v", b)
+		}
+	})
+}
+
+// TestStringN checks that StringN renders every set type with the same type-specific prefix as its String method.
+func TestStringN(t *testing.T) {
+	t.Parallel()
+
+	want := map[string]string{
+		"Map":           "Set[int]",
+		"SyncMap":       "SyncSet[int]",
+		"Locked":        "LockedSet[int]",
+		"Ordered":       "OrderedSet[int]",
+		"LockedOrdered": "LockedOrderedSet[int]",
+		"SortedSet":     "SortedSet[int]",
+		"BitSet":        "BitSet[int]",
+		"Bag":           "Bag[int]",
+		"SmallSet":      "SmallSet[int]",
+		"Hybrid":        "Hybrid[int]",
+		"Bounded":       "BoundedSet[int]",
+		"Frozen":        "Frozen[int]",
+		"PrioritySet":   "PrioritySet[int]",
+		"Observable":    "ObservableSet[int]",
+		"LockedBag":     "LockedBag[int]",
+		"LockedFrozen":  "LockedFrozen[int]",
+	}
+	constructors := maps.Clone(intSetConstructors)
+	constructors["Observable"] = func(m ...int) Set[int] { return NewObservable[int](NewWith(m...)) }
+	constructors["LockedBag"] = func(m ...int) Set[int] { return NewLockedWrapping[int](NewBagWith(m...)) }
+	constructors["LockedFrozen"] = func(m ...int) Set[int] {
+		return NewLockedWrapping[int](NewBuilder[int]().Add(m...).Build())
+	}
+	for name, newSet := range constructors {
+		prefix, ok := want[name]
+		if !ok {
+			t.Fatalf("%s: no expected prefix", name)
+		}
+		s := newSet(1, 2, 3, 4, 5)
+		got := StringN(s, 2)
+		elems, found := strings.CutPrefix(got, prefix+"([")
+		elems, foundSuffix := strings.CutSuffix(elems, " ...(5 total)])")
+		if !found || !foundSuffix || len(strings.Fields(elems)) != 2 {
+			t.Errorf("%s: StringN(%v, 2) = %q, want %s([<2 elements> ...(5 total)])", name, s, got, prefix)
+		}
+	}
+
+	ts := NewTimeSetFrom(time.Second, slices.Values([]time.Time{time.Unix(1, 0), time.Unix(2, 0)}))
+	if got := StringN[time.Time](ts, 0); got != "TimeSet[1s]([...(2 total)])" {
+		t.Errorf("StringN(TimeSet, 0) = %q", got)
+	}
+	// a negative n is 0, so an empty set renders as String does
+	for _, s := range []Set[int]{NewOrdered[int](), NewBuilder[int]().Build()} {
+		if got, want := StringN(s, -1), s.String(); got != want {
+			t.Errorf("StringN(%T, -1) = %q, want %q", s, got, want)
+		}
+	}
+	if got, want := StringN[int](NewOrderedWith(1, 2), -1), "OrderedSet[int]([...(2 total)])"; got != want {
+		t.Errorf("StringN(-1) = %q, want %q", got, want)
+	}
+}
@@ -1,9 +1,13 @@
 package sets
 
 import (
+	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"slices"
+	"strings"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -112,6 +116,11 @@ func ExampleOrderedSet() {
 		fmt.Println("3 is at position 1")
 	}
 
+	// negative positions count back from the end
+	if v, ok := ints.At(-1); v == 6 && ok {
+		fmt.Println("6 is at position -1")
+	}
+
 	if ints.Index(3) == 1 {
 		fmt.Println("3 is at index 1")
 	}
@@ -139,6 +148,7 @@ func ExampleOrderedSet() {
 	// 1 3
 	// 0 5
 	// 3 is at position 1
+	// 6 is at position -1
 	// 3 is at index 1
 	// 100 is not present
 	// 1
@@ -181,6 +191,19 @@ func ExampleRemoveSeq() {
 	// Output: 1
 }
 
+func ExampleTee() {
+	words := slices.Values([]string{"a", "b", "a", "c", "b"})
+
+	out, seen := Tee(words)
+	fmt.Println(slices.Collect(out))
+
+	// the set is complete now that out has been fully consumed
+	fmt.Println(seen.Cardinality())
+	// Output:
+	// [a b a c b]
+	// 3
+}
+
 func ExampleUnion() {
 	a := NewWith(5, 3)
 	b := NewWith(3, 2)
@@ -216,6 +239,15 @@ func ExampleIntersection() {
 	// 3
 }
 
+func ExampleIntersectionSeqs() {
+	users := NewOrderedWith("ann", "bob", "cat", "dan")
+	monday := slices.Values([]string{"dan", "bob", "eve", "ann"})
+	tuesday := slices.Values([]string{"ann", "dan", "fay"})
+
+	fmt.Println(IntersectionSeqs(users, monday, tuesday))
+	// Output: OrderedSet[string]([ann dan])
+}
+
 func ExampleDifference() {
 	a := NewWith(5, 3)
 	b := NewWith(3, 2)
@@ -232,6 +264,62 @@ func ExampleDifference() {
 	// 5
 }
 
+func ExampleComplement() {
+	permissions := NewOrderedWith("read", "write", "delete", "admin")
+	granted := NewWith("read", "write")
+
+	fmt.Println(Complement[string](permissions, granted))
+
+	_, err := ComplementStrict[string](permissions, NewWith("read", "execute"))
+	fmt.Println(err)
+	// Output:
+	// OrderedSet[string]([delete admin])
+	// complement: element execute is not in the universe
+}
+
+func ExampleDifferenceIter() {
+	stored := NewOrderedWith("alice", "bob", "carol", "dave")
+	current := NewWith("bob", "dave")
+
+	for name := range DifferenceIter(stored, current) {
+		fmt.Println("deleting", name)
+	}
+	// Output:
+	// deleting alice
+	// deleting carol
+}
+
+func ExampleUnionIter() {
+	a := NewOrderedWith(1, 3, 5)
+	b := NewOrderedWith(5, 4, 3, 2)
+
+	fmt.Println(slices.Collect(UnionIter(a, b)))
+	fmt.Println(slices.Collect(IntersectionIter(a, b)))
+	// Output:
+	// [1 3 5 4 2]
+	// [3 5]
+}
+
+func ExampleAppendSeqErr() {
+	dec := json.NewDecoder(strings.NewReader(`"a" "b" "a" 7 "c"`))
+	tokens := func(yield func(string, error) bool) {
+		for dec.More() {
+			var v string
+			if err := dec.Decode(&v); !yield(v, err) || err != nil {
+				return
+			}
+		}
+	}
+
+	set := New[string]()
+	n, err := AppendSeqErr(set, tokens)
+	fmt.Println(n, err)
+	fmt.Println(slices.Sorted(set.Iterator))
+	// Output:
+	// 2 json: cannot unmarshal number into Go value of type string
+	// [a b]
+}
+
 func ExampleSymmetricDifference() {
 	a := NewWith(5, 3)
 	b := NewWith(3, 2)
@@ -302,12 +390,73 @@ func ExampleEqual() {
 	// a and b are not equal now
 }
 
+func ExampleEqualExcept() {
+	deployed := NewWith("replicas", "image", "build_time")
+	desired := NewWith("replicas", "image", "commit")
+
+	volatile := NewWith("build_time", "commit")
+	fmt.Println(Equal[string](deployed, desired))
+	fmt.Println(EqualExcept[string](deployed, desired, volatile))
+	// Output:
+	// false
+	// true
+}
+
+func ExampleEqualWithin() {
+	a := NewWith(0.1+0.2, 1.0/3.0)
+	b := NewWith(0.3, 0.333333)
+
+	if !Equal(a, b) {
+		fmt.Println("a and b are not exactly equal")
+	}
+	if EqualWithin(a, b, 1e-6) {
+		fmt.Println("a and b are equal within 1e-6")
+	}
+	if !EqualWithin(a, b, 1e-9) {
+		fmt.Println("a and b are not equal within 1e-9")
+	}
+	// Output:
+	// a and b are not exactly equal
+	// a and b are equal within 1e-6
+	// a and b are not equal within 1e-9
+}
+
+func ExampleEqualFunc() {
+	a := NewOrderedWith(1, 2, 3)
+	b := NewOrderedWith(3, 2, 1)
+
+	fmt.Println(EqualFunc(a, b, false))
+	fmt.Println(EqualFunc(a, b, true))
+	fmt.Println(EqualFunc[int](a, NewWith(3, 2, 1), true)) // unordered: compared as sets
+	// Output:
+	// true
+	// false
+	// true
+}
+
+func ExampleCompare() {
+	want := NewWith(1, 2, 3, 4)
+	got := NewOrderedWith(5, 3, 4)
+
+	if !Equal[int](got, want) {
+		fmt.Println(Compare[int](got, want))
+	}
+	// Output:
+	// only in a: [5]
+	// only in b: [1 2]
+	// common: 2
+}
+
 func ExampleContainsSeq() {
 	ints := New[int]()
 	if ContainsSeq(ints, slices.Values([]int{})) {
 		fmt.Println("Empty set contains empty sequence")
 	}
 
+	if !ContainsSeq(ints, slices.Values([]int{3})) {
+		fmt.Println("Empty set does not contain a non-empty sequence")
+	}
+
 	ints.Add(5)
 	ints.Add(3)
 	ints.Add(2)
@@ -325,6 +474,7 @@ func ExampleContainsSeq() {
 	}
 	// Output:
 	// Empty set contains empty sequence
+	// Empty set does not contain a non-empty sequence
 	// Non-empty set contains empty sequence
 	// 3 and 5 are present
 	// 6 is not present
@@ -347,6 +497,35 @@ func ExampleDisjoint() {
 	// a and b are not disjoint now
 }
 
+func ExampleIsPartition() {
+	shards := NewWith(0, 1, 2, 3, 4, 5)
+	a, b := NewWith(0, 2, 4), NewWith(1, 3)
+
+	fmt.Println(IsPartition[int](shards, a, b))
+	fmt.Println(ValidatePartition[int](shards, a, b))
+
+	b.Add(5)
+	fmt.Println(IsPartition[int](shards, a, b))
+	fmt.Println(ValidatePartition[int](shards, a, b))
+	// Output:
+	// false
+	// invalid partition: 1 element(s) of the universe are in no part, including 5
+	// true
+	// <nil>
+}
+
+func ExampleDisjointAll() {
+	odd := NewWith(1, 3, 5)
+	even := NewWith(2, 4, 6)
+	primes := NewWith(2, 3, 5)
+
+	fmt.Println(DisjointAll[int](odd, even))
+	fmt.Println(DisjointAll[int](odd, even, primes))
+	// Output:
+	// true
+	// false
+}
+
 func ExampleEqualOrdered() {
 	a := NewOrderedWith(5, 3, 1)
 	b := NewOrderedWith(5, 3, 1)
@@ -460,6 +639,18 @@ func ExampleChunk() {
 	// 5
 }
 
+func ExampleSortedIterator() {
+	set := NewWith(3, 1, 2)
+
+	for v := range SortedIterator(set) {
+		fmt.Println(v)
+	}
+	// Output:
+	// 1
+	// 2
+	// 3
+}
+
 func ExampleIter2() {
 	ints := NewOrderedWith(1, 2, 3, 4, 5)
 
@@ -675,6 +866,16 @@ func ExampleNewSyncMapFrom() {
 	// Output: 3
 }
 
+func ExampleSyncMap_Snapshot() {
+	set := NewSyncMapWith("c", "a", "b")
+
+	elems := set.Snapshot()
+	slices.Sort(elems)
+	fmt.Println(elems)
+
+	// Output: [a b c]
+}
+
 func ExampleNewLockedWrapping() {
 	set := NewWith("a", "b", "c", "b")
 
@@ -710,6 +911,13 @@ func ExampleIsEmpty() {
 	// set is not empty
 }
 
+func ExampleLen() {
+	set := NewWith(1, 2, 3)
+
+	fmt.Println(Len(set), Len(set) == set.Cardinality())
+	// Output: 3 true
+}
+
 func ExampleMapBy() {
 	set := NewWith(1, 2, 3)
 
@@ -790,6 +998,16 @@ func ExampleReduce() {
 	// Output: 6
 }
 
+func ExampleHistogram() {
+	latencies := NewWith(3, 8, 12, 15, 27, 41, 45)
+
+	byTens := Histogram(latencies, func(ms int) string {
+		return fmt.Sprintf("%d-%dms", ms/10*10, ms/10*10+9)
+	})
+	fmt.Println(byTens) // fmt prints maps with sorted keys
+	// Output: map[0-9ms:2 10-19ms:2 20-29ms:1 40-49ms:2]
+}
+
 func ExampleReduceRight() {
 	set := NewOrderedWith(3, 1, 2)
 
@@ -875,6 +1093,21 @@ func ExampleContainsAll() {
 	// set does not contain both 1 and 6
 }
 
+func ExampleContainsBy() {
+	type user struct {
+		id    int
+		email string
+	}
+	users := NewWith(user{1, "ada@example.com"}, user{2, "bob@example.com"})
+	email := func(u user) string { return u.email }
+
+	fmt.Println(ContainsBy(users, "bob@example.com", email))
+	fmt.Println(ContainsBy(users, "eve@example.com", email))
+	// Output:
+	// true
+	// false
+}
+
 func ExampleContainsAny() {
 	set := NewWith(1, 2, 3)
 
@@ -890,6 +1123,19 @@ func ExampleContainsAny() {
 	// set contains none of 6, 7, 8
 }
 
+func ExampleContainsEach() {
+	allowed := NewWith("alice", "bob")
+
+	users := []string{"bob", "mallory", "alice"}
+	for i, ok := range ContainsEach(allowed, users) {
+		fmt.Println(users[i], ok)
+	}
+	// Output:
+	// bob true
+	// mallory false
+	// alice true
+}
+
 func ExampleRandom() {
 	set := NewWith(42)
 
@@ -1064,3 +1310,458 @@ func ExampleBitSet_Range() {
 	// 30
 	// 40
 }
+
+func ExampleStringN() {
+	set := NewOrderedWith(5, 3, 1, 4, 2)
+
+	fmt.Println(StringN(set, 3))
+	fmt.Println(StringN(set, 0))
+	fmt.Println(StringN(set, 10))
+	// Output:
+	// OrderedSet[int]([5 3 1 ...(5 total)])
+	// OrderedSet[int]([...(5 total)])
+	// OrderedSet[int]([5 3 1 4 2])
+}
+
+func ExampleElementTypeName() {
+	fmt.Println(ElementTypeName[int](NewOrdered[int]()))
+	fmt.Println(ElementTypeName(NewWith(time.Second)))
+	// Output:
+	// int
+	// time.Duration
+}
+
+func ExampleNewBagWith() {
+	words := NewBagWith("a", "b", "b", "c", "c", "c")
+
+	fmt.Println(words.Cardinality(), words.Total())
+	fmt.Println(words.Count("b"))
+	fmt.Println(words.MostCommon(2))
+
+	words.Remove("c") // removes one occurrence
+	fmt.Println(words.Count("c"), words.Contains("c"))
+	// Output:
+	// 3 6
+	// 2
+	// [c b]
+	// 2 true
+}
+
+func ExampleOrdered_MoveToBack() {
+	// least recently used first
+	lru := NewOrderedWith("a", "b", "c")
+
+	lru.MoveToBack("a") // "a" was just used
+	lru.Add("d")
+
+	// evict the least recently used element
+	if oldest, ok := First[string](lru); ok {
+		lru.Remove(oldest)
+		fmt.Println("evicted", oldest)
+	}
+	fmt.Println(Elements[string](lru))
+	// Output:
+	// evicted b
+	// [c a d]
+}
+
+func ExampleOrdered_Rotate() {
+	workers := NewOrderedWith("w1", "w2", "w3")
+
+	for range 4 {
+		next, _ := First[string](workers)
+		fmt.Println("dispatch to", next)
+		workers.Rotate(1)
+	}
+	// Output:
+	// dispatch to w1
+	// dispatch to w2
+	// dispatch to w3
+	// dispatch to w1
+}
+
+func ExampleTransform() {
+	tags := NewOrderedWith("Go", "sets", "GO", "Generics")
+	Transform(tags, strings.ToLower)
+
+	fmt.Println(tags)
+	// Output: OrderedSet[string]([go sets generics])
+}
+
+func ExampleLocked_Checkpoint() {
+	users := NewLockedWith("alice", "bob")
+
+	restore := users.Checkpoint()
+	for _, u := range []string{"carol", "", "dave"} {
+		if u == "" {
+			restore() // invalid batch: roll back everything added so far
+			break
+		}
+		users.Add(u)
+	}
+
+	names := Elements[string](users)
+	slices.Sort(names)
+	fmt.Println(names)
+	// Output: [alice bob]
+}
+
+func ExampleOrdered_Merge() {
+	seen := NewOrderedWith("a", "b")
+	added := seen.Merge(NewOrderedWith("b", "c", "d"))
+
+	fmt.Println(added, seen)
+	// Output: 2 OrderedSet[string]([a b c d])
+}
+
+func ExampleOrdered_RemoveAll() {
+	queue := NewOrderedWith("a", "b", "c", "d")
+	removed := queue.RemoveAll(NewWith("b", "d", "e"))
+
+	fmt.Println(removed, queue)
+	// Output: 2 OrderedSet[string]([a c])
+}
+
+func ExampleOrdered_AtMany() {
+	s := NewOrderedWith("a", "b", "c", "d", "e")
+
+	fmt.Printf("%q\n", s.AtMany(4, 0, -2, 9))
+	// Output: ["e" "a" "d" ""]
+}
+
+func ExampleOrdered_Keep() {
+	queue := NewOrderedWith("a", "b", "c", "d")
+	removed := queue.Keep(NewWith("b", "d", "e"))
+
+	fmt.Println(removed, queue)
+	// Output: 2 OrderedSet[string]([b d])
+}
+
+func ExamplePrioritySet() {
+	jobs := NewPrioritySet[string]()
+	jobs.AddWithPriority("backup", 1)
+	jobs.AddWithPriority("deploy", 5)
+	jobs.AddWithPriority("report", 3)
+	jobs.AddWithPriority("backup", 1) // already queued: deduplicated
+
+	for job, ok := jobs.PopHighest(); ok; job, ok = jobs.PopHighest() {
+		fmt.Println(job)
+	}
+	// Output:
+	// deploy
+	// report
+	// backup
+}
+
+func ExampleSmallSet() {
+	tags := NewSmallSetWith("go", "sets", "go")
+	tags.Add("generics")
+
+	fmt.Println(tags.Contains("sets"), tags)
+	// Output: true SmallSet[string]([go sets generics])
+}
+
+func ExampleHybrid() {
+	s := NewHybridThreshold[int](2)
+	s.Add(1)
+	s.Add(2)
+	fmt.Println(s.Promoted())
+	s.Add(3)
+	fmt.Println(s.Promoted(), s.Cardinality())
+	// Output:
+	// false
+	// true 3
+}
+
+func ExampleSplitDifference() {
+	desired := NewOrderedWith("web", "db", "cache")
+	actual := NewOrderedWith("db", "queue")
+
+	create, keep, remove := SplitDifference(desired, actual)
+	fmt.Println(create, keep, remove)
+	// Output: OrderedSet[string]([web cache]) OrderedSet[string]([db]) OrderedSet[string]([queue])
+}
+
+func ExampleMissing() {
+	known := NewOrderedWith(1, 2, 3)
+	batch := slices.Values([]int{4, 2, 5, 4})
+
+	fmt.Println(Missing(known, batch), Present(known, batch))
+	// Output: OrderedSet[int]([4 5]) OrderedSet[int]([2])
+}
+
+func ExampleBytesSet() {
+	seen := NewBytesSet()
+	for _, chunk := range [][]byte{[]byte("abc"), []byte("def"), []byte("abc")} {
+		sum := sha256.Sum256(chunk)
+		if !seen.Add(sum[:]) {
+			fmt.Printf("duplicate chunk %q\n", chunk)
+		}
+	}
+	fmt.Println(seen.Cardinality())
+	// Output:
+	// duplicate chunk "abc"
+	// 2
+}
+
+func ExampleNewTimeSet() {
+	visits := NewTimeSet(time.Minute)
+	visits.Add(time.Date(2024, 5, 1, 9, 30, 10, 0, time.UTC))
+	visits.Add(time.Date(2024, 5, 1, 9, 30, 50, 0, time.UTC))                     // same minute
+	visits.Add(time.Date(2024, 5, 1, 11, 30, 0, 0, time.FixedZone("CEST", 7200))) // same minute, elsewhere
+	visits.Add(time.Date(2024, 5, 1, 9, 31, 0, 0, time.UTC))
+
+	fmt.Println(visits.Cardinality())
+	// Output: 2
+}
+
+func ExampleOrdered_Head() {
+	scores := NewOrderedWith(70, 95, 88, 60, 91)
+	scores.Sort()
+
+	fmt.Println(scores.Head(2), scores.Tail(2))
+	// Output: OrderedSet[int]([60 70]) OrderedSet[int]([91 95])
+}
+
+func ExamplePopMin() {
+	queue := NewWith(30, 10, 20)
+	for v, ok := PopMin[int](queue); ok; v, ok = PopMin[int](queue) {
+		fmt.Println(v)
+	}
+	// Output:
+	// 10
+	// 20
+	// 30
+}
+
+func ExampleStats() {
+	latencies := NewLockedWith(120, 35, 80)
+
+	mn, mx, n, ok := Stats[int](latencies)
+	fmt.Println(mn, mx, n, ok)
+	// Output: 35 120 3 true
+}
+
+func ExampleEqualSlice() {
+	s := NewOrderedWith(3, 1, 2)
+
+	fmt.Println(EqualSlice(s, []int{1, 2, 3, 3}), EqualSliceOrdered(s, []int{1, 2, 3}), EqualSliceOrdered(s, []int{3, 1, 2}))
+	// Output: true false true
+}
+
+func ExampleHash() {
+	a := NewWith("x", "y", "z")
+	b := NewOrderedWith("z", "x", "y")
+
+	fmt.Println(Hash[string](a) == Hash[string](b))
+	// Output: true
+}
+
+func ExamplePrettyPrint() {
+	ports := NewWith(8080, 22, 443, 80, 5432, 3306, 6379)
+
+	fmt.Println(PrettyPrint[int](ports, 3))
+	// Output:
+	//   22    80   443
+	// 3306  5432  6379
+	// 8080
+}
+
+func ExampleNewFrozenKey() {
+	groups := New[FrozenKey[string]]()
+	groups.Add(NewFrozenKey[string](NewWith("alice", "bob")))
+	groups.Add(NewFrozenKey[string](NewOrderedWith("bob", "alice")))
+	groups.Add(NewFrozenKey[string](NewWith("carol")))
+
+	fmt.Println(groups.Cardinality())
+	// Output: 2
+}
+
+func ExampleBuilder() {
+	primes := NewBuilder[int]().
+		Add(7, 2, 5).
+		AddSeq(slices.Values([]int{3, 5, 2})).
+		Build()
+
+	fmt.Println(primes)
+	fmt.Println(primes.Contains(5), primes.Add(11), primes.Contains(11))
+	// Output:
+	// Frozen[int]([2 3 5 7])
+	// true false false
+}
+
+func ExampleNewBounded() {
+	recent := NewBounded[string](3)
+	for _, page := range []string{"home", "about", "home", "blog"} {
+		recent.Add(page)
+	}
+	fmt.Println(Elements(recent))
+
+	evicted, ok := recent.AddEvicting("contact")
+	fmt.Println(evicted, ok)
+	fmt.Println(Elements(recent))
+	// Output:
+	// [about home blog]
+	// about true
+	// [home blog contact]
+}
+
+func ExampleNewObservable() {
+	keys := NewObservable[string](New[string]())
+	keys.OnRemove(func(k string) {
+		fmt.Println("invalidate", k)
+	})
+
+	keys.Add("user:1")
+	keys.Remove("user:1")
+	keys.Remove("user:2") // not present: no hook
+	// Output:
+	// invalidate user:1
+}
+
+func ExampleToChannel() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel() // stops the sending goroutine if the loop exits early
+
+	for job := range ToChannel[string](ctx, NewOrderedWith("build", "test", "deploy")) {
+		fmt.Println("running", job)
+	}
+	// Output:
+	// running build
+	// running test
+	// running deploy
+}
+
+func ExampleFromChannel() {
+	results := make(chan string)
+	go func() {
+		defer close(results)
+		for _, r := range []string{"ok", "retry", "ok", "ok"} {
+			results <- r
+		}
+	}()
+
+	fmt.Println(OrderedFromChannel(context.Background(), results))
+	// Output:
+	// OrderedSet[string]([ok retry])
+}
+
+func ExampleNewMultiMap() {
+	tags := NewMultiMap[string, string](func() Set[string] { return NewOrdered[string]() })
+	tags.Add("go", "sets")
+	tags.Add("go", "iter")
+	tags.Add("db", "sets")
+
+	fmt.Println(tags.Get("go"))
+	fmt.Println(SortedSlice(tags.Keys()))
+	fmt.Println(tags.TotalValues())
+	// Output:
+	// OrderedSet[string]([sets iter])
+	// [db go]
+	// 3
+}
+
+func ExampleDecodeJSON() {
+	set := NewOrdered[string]()
+	n, err := DecodeJSON[string](strings.NewReader(`["b", "a", "b", "c"]`), set)
+	fmt.Println(n, err)
+	fmt.Println(set)
+	// Output:
+	// 3 <nil>
+	// OrderedSet[string]([b a c])
+}
+
+func ExampleMergeJSON() {
+	set := NewOrdered[string]()
+	for _, shard := range []string{`["a", "b"]`, `["b", "c"]`} {
+		n, err := MergeJSON[string](set, []byte(shard))
+		fmt.Println(n, err)
+	}
+	fmt.Println(set)
+	// Output:
+	// 2 <nil>
+	// 1 <nil>
+	// OrderedSet[string]([a b c])
+}
+
+func ExampleMarshalJSONSorted() {
+	set := NewSyncMapWith("c", "a", "b")
+
+	d, err := MarshalJSONSorted[string](set)
+	fmt.Println(string(d), err)
+	// Output:
+	// ["a","b","c"] <nil>
+}
+
+func ExampleSortBy() {
+	type user struct{ name string }
+	users := map[int]user{1: {"carol"}, 2: {"alice"}, 3: {"bob"}, 4: {"alice"}}
+
+	ids := NewOrderedWith(4, 3, 2, 1)
+	SortBy(ids, func(id int) string { return users[id].name })
+	fmt.Println(ids) // 4 stays before 2: both are "alice" and the sort is stable
+	// Output: OrderedSet[int]([4 2 3 1])
+}
+
+func ExampleCloneFunc() {
+	type config struct{ name string }
+	original := NewWith(&config{"a"}, &config{"b"})
+
+	copied := CloneFunc(original, func(c *config) *config {
+		dup := *c
+		return &dup
+	})
+	for c := range copied.Iterator {
+		c.name += "!" // modifies the copies only
+	}
+
+	name := func(c *config) string { return c.name }
+	fmt.Println(slices.Sorted(MapBy(original, name).Iterator))
+	fmt.Println(slices.Sorted(MapBy(copied, name).Iterator))
+	// Output:
+	// [a b]
+	// [a! b!]
+}
+
+func ExampleSortedSlice() {
+	fmt.Println(SortedSlice(NewWith(3, 1, 2)))
+	// Output: [1 2 3]
+}
+
+func ExampleConcat() {
+	pinned := NewOrderedWith("docs", "blog")
+	popular := NewOrderedWith("shop", "docs", "about")
+	recent := NewOrderedWith("about", "news")
+
+	fmt.Println(Elements(Concat(pinned, popular, recent)))
+	// Output:
+	// [docs blog shop about news]
+}
+
+func ExampleElementsOrdered() {
+	set := NewOrderedWith(5, 3, 1)
+	fmt.Println(ElementsOrdered(set))
+
+	sorted := NewSortedSetWith(5, 3, 1)
+	fmt.Println(ElementsOrdered(sorted))
+	// Output:
+	// [5 3 1]
+	// [1 3 5]
+}
+
+func ExampleNewFromString() {
+	alphabet := NewFromString("abcdefghijklmnopqrstuvwxyz ")
+
+	for _, text := range []string{"hello world", "Hello, World!"} {
+		fmt.Printf("%q uses only the alphabet: %v\n", text, Subset(NewFromString(text), alphabet))
+	}
+	// Output:
+	// "hello world" uses only the alphabet: true
+	// "Hello, World!" uses only the alphabet: false
+}
+
+func ExampleNewOrderedFromString() {
+	fmt.Println(NewOrderedFromString("mississippi"))
+	// Output:
+	// OrderedSet[int32]([109 105 115 112])
+}
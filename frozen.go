@@ -0,0 +1,227 @@
+package sets
+
+import (
+	"cmp"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"iter"
+)
+
+// ErrFrozen is returned, wrapped, by the decoding methods (UnmarshalJSON and Scan) of a Frozen set, which cannot be
+// modified after it is built.
+var ErrFrozen = errors.New("set is frozen")
+
+// Frozen is a read-only, always-sorted set, built with a Builder. Its elements are fixed when it is built: the
+// mutating methods (Add, Remove, Clear, Pop) are disabled and report that nothing changed, and UnmarshalJSON and Scan
+// return an error wrapping ErrFrozen. Because nothing can modify it, a Frozen set is safe to share between goroutines
+// without locking.
+//
+// Reads behave as on a SortedSet: Contains and Index are O(log N) binary searches, At, Max, and Min are O(1), and
+// Range(lo, hi) yields a sub-range in ascending order. The set-algebra and predicate optimization interfaces are
+// implemented with the same O(N+M) linear merges and scans as SortedSet whenever the other operand is a Frozen or a
+// SortedSet of the same element type.
+//
+// Clone, NewEmpty, and NewEmptyOrdered return a mutable *SortedSet, as do the set-algebra operations, so the
+// package-level functions that build new sets from a Frozen set work as they do for any other set.
+type Frozen[M cmp.Ordered] struct {
+	s SortedSet[M]
+}
+
+var _ OrderedSet[int] = new(Frozen[int])
+var _ driver.Valuer = new(Frozen[int])
+var _ Unioner[int] = new(Frozen[int])
+var _ Intersectioner[int] = new(Frozen[int])
+var _ Differencer[int] = new(Frozen[int])
+var _ SymmetricDifferencer[int] = new(Frozen[int])
+var _ Maxer[int] = new(Frozen[int])
+var _ Minner[int] = new(Frozen[int])
+var _ Equaler[int] = new(Frozen[int])
+var _ Disjointer[int] = new(Frozen[int])
+var _ Subsetter[int] = new(Frozen[int])
+
+// sorted returns the SortedSet view of the frozen set's elements. It must not be modified.
+func (f *Frozen[M]) sorted() *SortedSet[M] {
+	if f == nil {
+		return nil
+	}
+	return &f.s
+}
+
+// Contains returns true if the set contains the element.
+func (f *Frozen[M]) Contains(m M) bool {
+	return f.sorted().Contains(m)
+}
+
+// Clear is disabled: the set is left unchanged and 0 is returned.
+func (f *Frozen[M]) Clear() int {
+	return 0
+}
+
+// Drain is disabled: the set is left unchanged and nil is returned.
+func (f *Frozen[M]) Drain() []M {
+	return nil
+}
+
+// Add is disabled: the set is left unchanged and false is returned.
+func (f *Frozen[M]) Add(M) bool {
+	return false
+}
+
+// Remove is disabled: the set is left unchanged and false is returned.
+func (f *Frozen[M]) Remove(M) bool {
+	return false
+}
+
+// Pop is disabled: the set is left unchanged and the zero value of M and false are returned.
+func (f *Frozen[M]) Pop() (M, bool) {
+	var m M
+	return m, false
+}
+
+// Sort is a no-op: the set is always sorted in ascending order.
+func (f *Frozen[M]) Sort() {}
+
+// Cardinality returns the number of elements in the set.
+func (f *Frozen[M]) Cardinality() int {
+	return f.sorted().Cardinality()
+}
+
+// Iterator yields all elements in the set in ascending order.
+func (f *Frozen[M]) Iterator(yield func(M) bool) {
+	f.sorted().Iterator(yield)
+}
+
+// Ordered iteration yields the index and value of each element in the set in ascending order.
+func (f *Frozen[M]) Ordered(yield func(int, M) bool) {
+	f.sorted().Ordered(yield)
+}
+
+// Backwards iteration yields the index and value of each element in the set in descending order.
+func (f *Frozen[M]) Backwards(yield func(int, M) bool) {
+	f.sorted().Backwards(yield)
+}
+
+// Range returns an iterator over the elements v for which lo <= v <= hi, in ascending order. See SortedSet.Range.
+func (f *Frozen[M]) Range(lo, hi M) iter.Seq[M] {
+	return f.sorted().Range(lo, hi)
+}
+
+// At returns the element at the index. If the index is out of bounds, the second return value is false.
+func (f *Frozen[M]) At(i int) (M, bool) {
+	return f.sorted().At(i)
+}
+
+// Index returns the index of the element in the set, or -1 if not present.
+func (f *Frozen[M]) Index(m M) int {
+	return f.sorted().Index(m)
+}
+
+// Clone returns a mutable *SortedSet holding the same elements.
+func (f *Frozen[M]) Clone() Set[M] {
+	return f.sorted().Clone()
+}
+
+// NewEmpty returns a new, empty, mutable *SortedSet.
+func (f *Frozen[M]) NewEmpty() Set[M] {
+	return NewSortedSet[M]()
+}
+
+// NewEmptyOrdered returns a new, empty, mutable *SortedSet.
+func (f *Frozen[M]) NewEmptyOrdered() OrderedSet[M] {
+	return NewSortedSet[M]()
+}
+
+// String returns a string representation of the set. It returns a string of the form Frozen[T](<elements>).
+func (f *Frozen[M]) String() string {
+	var m M
+	return fmt.Sprintf("Frozen[%T](%v)", m, f.sorted().el)
+}
+
+// stringPrefix returns the prefix of String, as NewEmpty returns a SortedSet, whose prefix differs.
+//
+//lint:ignore U1000 reached via the stringPrefixer type assertion in stringPrefix
+func (f *Frozen[M]) stringPrefix() string {
+	var m M
+	return fmt.Sprintf("Frozen[%T]", m)
+}
+
+// Value implements the driver.Valuer interface. It returns the JSON representation of the set.
+func (f *Frozen[M]) Value() (driver.Value, error) {
+	return f.MarshalJSON()
+}
+
+// MarshalJSON implements json.Marshaler. It will marshal the set into a JSON array of the elements in the set in
+// ascending order. If the set is empty an empty JSON array is returned.
+func (f *Frozen[M]) MarshalJSON() ([]byte, error) {
+	d, err := f.sorted().MarshalJSON()
+	if err != nil {
+		return d, fmt.Errorf("marshaling frozen set: %w", err)
+	}
+	return d, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A frozen set cannot be modified, so it always returns an error wrapping
+// ErrFrozen and the set is left unchanged.
+func (f *Frozen[M]) UnmarshalJSON([]byte) error {
+	return fmt.Errorf("unmarshaling frozen set: %w", ErrFrozen)
+}
+
+// Scan implements the sql.Scanner interface. A frozen set cannot be modified, so it always returns an error wrapping
+// ErrFrozen and the set is left unchanged.
+func (f *Frozen[M]) Scan(any) error {
+	return fmt.Errorf("scanning frozen set: %w", ErrFrozen)
+}
+
+// Max implements Maxer: it returns the largest element in O(1). The second return value is false if the set is empty.
+func (f *Frozen[M]) Max() (M, bool) {
+	return f.sorted().Max()
+}
+
+// Min implements Minner: it returns the smallest element in O(1). The second return value is false if the set is
+// empty.
+func (f *Frozen[M]) Min() (M, bool) {
+	return f.sorted().Min()
+}
+
+// Union implements Unioner with SortedSet's linear merge when other is a Frozen or *SortedSet of the same element
+// type. The result is a mutable *SortedSet.
+func (f *Frozen[M]) Union(other Set[M]) (Set[M], bool) {
+	return f.sorted().Union(other)
+}
+
+// Intersection implements Intersectioner with SortedSet's linear merge when other is a Frozen or *SortedSet of the
+// same element type. The result is a mutable *SortedSet.
+func (f *Frozen[M]) Intersection(other Set[M]) (Set[M], bool) {
+	return f.sorted().Intersection(other)
+}
+
+// Difference implements Differencer with SortedSet's linear merge when other is a Frozen or *SortedSet of the same
+// element type. The result is a mutable *SortedSet.
+func (f *Frozen[M]) Difference(other Set[M]) (Set[M], bool) {
+	return f.sorted().Difference(other)
+}
+
+// SymmetricDifference implements SymmetricDifferencer with SortedSet's linear merge when other is a Frozen or
+// *SortedSet of the same element type. The result is a mutable *SortedSet.
+func (f *Frozen[M]) SymmetricDifference(other Set[M]) (Set[M], bool) {
+	return f.sorted().SymmetricDifference(other)
+}
+
+// Equal implements Equaler with SortedSet's element-wise comparison when other is a Frozen or *SortedSet of the same
+// element type.
+func (f *Frozen[M]) Equal(other Set[M]) (bool, bool) {
+	return f.sorted().Equal(other)
+}
+
+// Disjoint implements Disjointer with SortedSet's two-pointer scan when other is a Frozen or *SortedSet of the same
+// element type.
+func (f *Frozen[M]) Disjoint(other Set[M]) (bool, bool) {
+	return f.sorted().Disjoint(other)
+}
+
+// Subset implements Subsetter with SortedSet's two-pointer scan when other is a Frozen or *SortedSet of the same
+// element type.
+func (f *Frozen[M]) Subset(other Set[M]) (bool, bool) {
+	return f.sorted().Subset(other)
+}
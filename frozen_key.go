@@ -0,0 +1,79 @@
+package sets
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"reflect"
+	"slices"
+)
+
+// FrozenKey is an immutable, comparable snapshot of a set's elements, so that sets can themselves be set elements or
+// map keys: a Set[FrozenKey[K]] is a set of sets, e.g. a family of subsets. Two keys are == exactly when they were made
+// from sets holding the same elements, whatever the sets' types or orders. The zero value is the key of the empty set.
+//
+// The elements are held in a sorted, compact encoding (that of MarshalBinary, without the header), so a key costs
+// roughly as much memory as the binary form of its set. A negative zero is stored as positive zero, since sets treat
+// them as the same element. NaN elements make a key unreliable, as NaN != NaN: two sets each holding a NaN are not
+// Equal, yet their keys are ==.
+type FrozenKey[K cmp.Ordered] struct {
+	enc string // sorted elements, each encoded as by MarshalBinary
+	n   int
+}
+
+// NewFrozenKey returns the key of the set's current elements. Later changes to the set do not affect the key. A nil set
+// has the key of the empty set.
+func NewFrozenKey[K cmp.Ordered](s Set[K]) FrozenKey[K] {
+	enc, _, err := binaryCodec[K]()
+	if err != nil {
+		panic("sets.NewFrozenKey: " + err.Error()) // unreachable: every cmp.Ordered kind has an encoding
+	}
+	elems := SortedSlice(orEmpty(s))
+	var zero K
+	var d []byte
+	for _, k := range elems {
+		if k == zero { // -0.0 == 0.0, so this canonicalizes the sign of a floating point zero
+			k = zero
+		}
+		d = enc(d, reflect.ValueOf(k))
+	}
+	return FrozenKey[K]{enc: string(d), n: len(elems)}
+}
+
+// Len returns the number of elements in the key's set.
+func (k FrozenKey[K]) Len() int {
+	return k.n
+}
+
+// Iterator yields the key's elements in ascending order.
+func (k FrozenKey[K]) Iterator(yield func(K) bool) {
+	_, dec, _ := binaryCodec[K]()
+	d := []byte(k.enc)
+	for range k.n {
+		var m K
+		var err error
+		if d, err = dec(d, reflect.ValueOf(&m).Elem()); err != nil {
+			panic("sets.FrozenKey: corrupt encoding: " + err.Error()) // unreachable: only NewFrozenKey encodes
+		}
+		if !yield(m) {
+			return
+		}
+	}
+}
+
+// Elements returns the key's elements in ascending order.
+func (k FrozenKey[K]) Elements() []K {
+	return slices.AppendSeq(make([]K, 0, k.n), iter.Seq[K](k.Iterator))
+}
+
+// Set returns a new, mutable *SortedSet[K] holding the key's elements. Use AppendSeq with the key's Iterator to fill a
+// set of another type.
+func (k FrozenKey[K]) Set() *SortedSet[K] {
+	return &SortedSet[K]{el: k.Elements()}
+}
+
+// String returns a string representation of the key's elements, in ascending order.
+func (k FrozenKey[K]) String() string {
+	var m K
+	return fmt.Sprintf("FrozenKey[%T](%v)", m, k.Elements())
+}
@@ -0,0 +1,81 @@
+package sets
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// TestFrozenKey_Model checks that keys are == exactly when their sets are Equal, whatever the sets' types and element
+// orders, and that a key converts back to its set.
+func TestFrozenKey_Model(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(t *rapid.T) {
+		as := rapid.SliceOfN(rapid.IntRange(-20, 20), 0, 10).Draw(t, "a")
+		bs := rapid.SliceOfN(rapid.IntRange(-20, 20), 0, 10).Draw(t, "b")
+		a, b := NewOrderedWith(as...), NewWith(bs...)
+
+		ka, kb := NewFrozenKey[int](a), NewFrozenKey[int](b)
+		if got, want := ka == kb, Equal[int](a, b); got != want {
+			t.Fatalf("NewFrozenKey(%v) == NewFrozenKey(%v) is %v, want %v", a, b, got, want)
+		}
+		if ka.Len() != a.Cardinality() {
+			t.Fatalf("Len() = %d, want %d", ka.Len(), a.Cardinality())
+		}
+		if got, want := ka.Elements(), SortedSlice[int](a); !slices.Equal(got, want) {
+			t.Fatalf("Elements() = %v, want %v", got, want)
+		}
+		if s := ka.Set(); !EqualOrdered[int](s, NewSortedSetWith(as...)) {
+			t.Fatalf("Set() = %v, want %v", s, a)
+		}
+	})
+}
+
+func TestFrozenKey_SetOfSets(t *testing.T) {
+	t.Parallel()
+
+	family := New[FrozenKey[string]]()
+	family.Add(NewFrozenKey[string](NewWith("a", "b")))
+	family.Add(NewFrozenKey[string](NewOrderedWith("b", "a")))
+	family.Add(NewFrozenKey[string](NewSortedSetWith("c")))
+	family.Add(FrozenKey[string]{})
+	if family.Cardinality() != 3 {
+		t.Fatalf("family = %v, want 3 distinct subsets", family)
+	}
+	if !family.Contains(NewFrozenKey[string](nil)) {
+		t.Fatal("the zero FrozenKey is not the key of the empty set")
+	}
+	if got, want := NewFrozenKey[string](NewWith("b", "a")).String(), "FrozenKey[string]([a b])"; got != want {
+		t.Fatalf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestFrozenKey_Floats(t *testing.T) {
+	t.Parallel()
+
+	if NewFrozenKey[float64](NewWith(math.Copysign(0, -1), 1.5)) != NewFrozenKey[float64](NewWith(0, 1.5)) {
+		t.Fatal("keys of sets holding -0 and +0 differ")
+	}
+	k := NewFrozenKey[float32](NewWith[float32](2.5, -1))
+	if got := k.Elements(); !slices.Equal(got, []float32{-1, 2.5}) {
+		t.Fatalf("Elements() = %v", got)
+	}
+}
+
+func TestFrozenKey_Snapshot(t *testing.T) {
+	t.Parallel()
+
+	s := NewWith(1, 2)
+	k := NewFrozenKey[int](s)
+	s.Add(3)
+	if got := k.Elements(); !slices.Equal(got, []int{1, 2}) {
+		t.Fatalf("key changed with its set: %v", got)
+	}
+	k.Set().Add(4)
+	if k.Len() != 2 {
+		t.Fatalf("key changed with its Set(): %v", k)
+	}
+}
@@ -0,0 +1,287 @@
+package sets
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"slices"
+)
+
+// DefaultHybridThreshold is the cardinality past which a Hybrid made by NewHybrid moves from a slice to a map. It is
+// near where BenchmarkHybrid shows Map's Contains overtaking SmallSet's for int elements.
+const DefaultHybridThreshold = 16
+
+// Hybrid is a set that starts out as a SmallSet and switches to a Map once its cardinality exceeds a threshold, so that
+// it is cheap for the many sets that stay tiny without turning O(n) for the few that grow. The switch is transparent:
+// every method behaves the same before and after it, except for the order of iteration, which is insertion order while
+// the set is slice-backed and not defined once it is map-backed. The switch is one way, so that a set whose size
+// hovers around the threshold is not copied back and forth; only Clear and Drain return the set to a slice. It is not
+// safe for concurrent use. The set must not be modified during iteration.
+//
+// Hybrid's zero value is ready to use, with the DefaultHybridThreshold.
+type Hybrid[M comparable] struct {
+	small     SmallSet[M] // the elements, until the set is promoted
+	big       *Map[M]     // the elements once the set is promoted; nil before
+	threshold int         // 0 for the zero value, meaning DefaultHybridThreshold; -1 for a threshold of 0
+}
+
+var _ Set[int] = new(Hybrid[int])
+var _ driver.Valuer = new(Hybrid[int])
+var _ capacitySet = new(Hybrid[int])
+
+// NewHybrid returns an empty *Hybrid[M] that switches to a map past DefaultHybridThreshold elements.
+func NewHybrid[M comparable]() *Hybrid[M] {
+	return &Hybrid[M]{}
+}
+
+// NewHybridThreshold returns an empty *Hybrid[M] that switches to a map once it holds more than threshold elements. A
+// threshold of 0 makes it a map from the first element. It panics if threshold is negative.
+func NewHybridThreshold[M comparable](threshold int) *Hybrid[M] {
+	if threshold < 0 {
+		panic("sets.NewHybridThreshold: threshold must be >= 0")
+	}
+	if threshold == 0 {
+		threshold = -1 // 0 stands for the zero value's default
+	}
+	return &Hybrid[M]{threshold: threshold}
+}
+
+// NewHybridFrom returns a new *Hybrid[M], with the DefaultHybridThreshold, filled with the values from the sequence.
+func NewHybridFrom[M comparable](seq iter.Seq[M]) *Hybrid[M] {
+	s := NewHybrid[M]()
+	for x := range seq {
+		s.Add(x)
+	}
+	return s
+}
+
+// NewHybridWith returns a new *Hybrid[M], with the DefaultHybridThreshold, with the values provided.
+func NewHybridWith[M comparable](m ...M) *Hybrid[M] {
+	return NewHybridFrom(slices.Values(m))
+}
+
+// Threshold returns the cardinality past which the set switches from a slice to a map.
+func (s *Hybrid[M]) Threshold() int {
+	switch {
+	case s == nil || s.threshold == 0:
+		return DefaultHybridThreshold
+	case s.threshold < 0:
+		return 0
+	}
+	return s.threshold
+}
+
+// Promoted reports whether the set has switched to a map.
+func (s *Hybrid[M]) Promoted() bool {
+	return s != nil && s.big != nil
+}
+
+// promote moves the elements to a map with room for n more elements, if the set is still slice-backed and n more
+// elements would take it past the threshold.
+func (s *Hybrid[M]) promote(n int) {
+	if s.big != nil || len(s.small.el)+n <= s.Threshold() {
+		return
+	}
+	s.big = &Map[M]{set: make(map[M]struct{}, len(s.small.el)+n), hw: len(s.small.el) + n}
+	for _, x := range s.small.el {
+		s.big.set[x] = struct{}{}
+	}
+	s.small = SmallSet[M]{}
+}
+
+// Contains returns true if the set contains the element.
+func (s *Hybrid[M]) Contains(m M) bool {
+	if s == nil {
+		return false
+	}
+	if s.big != nil {
+		return s.big.Contains(m)
+	}
+	return s.small.Contains(m)
+}
+
+// Clear the set and returns the number of elements removed. The set returns to a slice.
+func (s *Hybrid[M]) Clear() int {
+	n := s.Cardinality()
+	s.big = nil
+	s.small.Clear()
+	return n
+}
+
+// Drain removes all elements from the set and returns them. Returns nil if the set is empty. The set returns to a
+// slice.
+func (s *Hybrid[M]) Drain() []M {
+	if s.big != nil {
+		out := s.big.Drain()
+		s.big = nil
+		return out
+	}
+	return s.small.Drain()
+}
+
+// Add an element to the set. Returns true if the element was added, false if it was already present. Adding the
+// element that takes the set past its threshold moves the set to a map.
+func (s *Hybrid[M]) Add(m M) bool {
+	if s.big != nil {
+		return s.big.Add(m)
+	}
+	if s.small.Contains(m) {
+		return false
+	}
+	if s.promote(1); s.big != nil {
+		return s.big.Add(m)
+	}
+	s.small.el = append(s.small.el, m)
+	return true
+}
+
+// Merge adds all of other's elements to the set in place and returns the number that were not already present. It is
+// the in-place form of Union, avoiding the copy Union makes.
+func (s *Hybrid[M]) Merge(other Set[M]) int {
+	return AppendSeq[M](s, orEmpty(other).Iterator)
+}
+
+// RemoveAll removes all of other's elements from the set in place and returns the number removed. It is the in-place
+// form of Difference, avoiding the copy Difference makes. A map-backed set stays map-backed.
+func (s *Hybrid[M]) RemoveAll(other Set[M]) int {
+	if Set[M](s) == other {
+		return s.Clear()
+	}
+	if s.big != nil {
+		return s.big.RemoveAll(other)
+	}
+	return s.small.RemoveAll(other)
+}
+
+// Keep removes every element that is not in other from the set in place and returns the number removed. It is the
+// in-place form of Intersection, avoiding the copy Intersection makes. A map-backed set stays map-backed.
+func (s *Hybrid[M]) Keep(other Set[M]) int {
+	if Set[M](s) == other {
+		return 0
+	}
+	if s.big != nil {
+		return s.big.Keep(other)
+	}
+	return s.small.Keep(other)
+}
+
+// Remove an element from the set. Returns true if the element was removed, false if it was not present. A map-backed
+// set stays map-backed.
+func (s *Hybrid[M]) Remove(m M) bool {
+	if s.big != nil {
+		return s.big.Remove(m)
+	}
+	return s.small.Remove(m)
+}
+
+// Cardinality returns the number of elements in the set.
+func (s *Hybrid[M]) Cardinality() int {
+	if s == nil {
+		return 0
+	}
+	if s.big != nil {
+		return s.big.Cardinality()
+	}
+	return len(s.small.el)
+}
+
+// Iterator yields all elements in the set: in insertion order while the set is slice-backed, in no defined order once
+// it is map-backed.
+func (s *Hybrid[M]) Iterator(yield func(M) bool) {
+	switch {
+	case s == nil:
+	case s.big != nil:
+		s.big.Iterator(yield)
+	default:
+		s.small.Iterator(yield)
+	}
+}
+
+// grow preallocates room for n more elements, moving the set to a map if they would take it past its threshold, even if
+// some of them turn out to be present already.
+//
+//lint:ignore U1000 reached via the capacitySet type assertion in the package-level grow
+func (s *Hybrid[M]) grow(n int) {
+	if s.promote(n); s.big != nil {
+		s.big.grow(n)
+		return
+	}
+	s.small.grow(n)
+}
+
+// Clone returns a copy of the set: a *Hybrid[M] with the same threshold and backing, so a clone of a map-backed set
+// is map-backed too.
+func (s *Hybrid[M]) Clone() Set[M] {
+	if s == nil {
+		return NewHybrid[M]()
+	}
+	c := &Hybrid[M]{small: SmallSet[M]{el: slices.Clone(s.small.el)}, threshold: s.threshold}
+	if s.big != nil {
+		c.big = s.big.Clone().(*Map[M])
+	}
+	return c
+}
+
+// NewEmpty returns a new empty, slice-backed *Hybrid[M] with the same threshold.
+func (s *Hybrid[M]) NewEmpty() Set[M] {
+	if s == nil {
+		return NewHybrid[M]()
+	}
+	return &Hybrid[M]{threshold: s.threshold}
+}
+
+// Pop removes and returns an element from the set. If the set is empty, it returns the zero value of M and false. A
+// map-backed set stays map-backed.
+func (s *Hybrid[M]) Pop() (M, bool) {
+	if s.big != nil {
+		return s.big.Pop()
+	}
+	return s.small.Pop()
+}
+
+// String representation of the set. It returns a string of the form Hybrid[T](<elements>).
+func (s *Hybrid[M]) String() string {
+	var m M
+	return fmt.Sprintf("Hybrid[%T](%v)", m, Elements[M](s))
+}
+
+// MarshalJSON marshals the set to JSON. It returns a JSON array of the elements in the set. If the set is empty, it
+// returns an empty JSON array.
+func (s *Hybrid[M]) MarshalJSON() ([]byte, error) {
+	if s.Cardinality() == 0 {
+		return []byte("[]"), nil
+	}
+	d, err := json.Marshal(Elements[M](s))
+	if err != nil {
+		return d, fmt.Errorf("marshaling hybrid set: %w", err)
+	}
+	return d, nil
+}
+
+// UnmarshalJSON unmarshals the set from JSON. It expects a JSON array of the elements in the set, which are added as
+// by Add, so a long array moves the set to a map. A JSON null leaves the set empty. If the JSON is invalid, it returns
+// an error and the set is left unchanged.
+func (s *Hybrid[M]) UnmarshalJSON(d []byte) error {
+	var um []M
+	if err := json.Unmarshal(d, &um); err != nil {
+		return fmt.Errorf("unmarshaling hybrid set: %w", err)
+	}
+	s.Clear()
+	for _, m := range um {
+		s.Add(m)
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface. It returns the JSON representation of the set.
+func (s *Hybrid[M]) Value() (driver.Value, error) {
+	return s.MarshalJSON()
+}
+
+// Scan implements the sql.Scanner interface. It scans the value from the database into the set. It expects a JSON
+// array of the elements in the set. If the JSON is invalid an error is returned. If the value is nil an empty set is
+// returned.
+func (s *Hybrid[M]) Scan(src any) error {
+	return scanValue[M](src, s.Clear, s.UnmarshalJSON)
+}
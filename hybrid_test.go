@@ -0,0 +1,135 @@
+package sets
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// TestHybrid_Model checks Hybrid against a Map across random Add/Remove/Pop/Clear/Merge/Keep sequences and thresholds,
+// and that it is map-backed exactly when it has grown past its threshold since it was last emptied by Clear.
+func TestHybrid_Model(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(t *rapid.T) {
+		threshold := rapid.IntRange(0, 8).Draw(t, "Threshold")
+		s := NewHybridThreshold[int](threshold)
+		model := New[int]()
+		promoted := false
+
+		steps := rapid.IntRange(1, 200).Draw(t, "Steps")
+		for range steps {
+			v := rapid.IntRange(-10, 10).Draw(t, "Value")
+			switch rapid.IntRange(0, 5).Draw(t, "Op") {
+			case 0:
+				if got, want := s.Add(v), model.Add(v); got != want {
+					t.Fatalf("Add(%d) = %v, want %v", v, got, want)
+				}
+			case 1:
+				if got, want := s.Remove(v), model.Remove(v); got != want {
+					t.Fatalf("Remove(%d) = %v, want %v", v, got, want)
+				}
+			case 2:
+				m, ok := s.Pop()
+				if ok != (model.Cardinality() > 0) || ok && !model.Remove(m) {
+					t.Fatalf("Pop() = %d, %v from %v", m, ok, model)
+				}
+			case 3:
+				if got, want := s.Clear(), model.Clear(); got != want {
+					t.Fatalf("Clear() = %d, want %d", got, want)
+				}
+				promoted = false
+			case 4:
+				other := NewWith(rapid.SliceOfN(rapid.IntRange(-10, 10), 0, 6).Draw(t, "Merge")...)
+				if got, want := s.Merge(other), model.Merge(other); got != want {
+					t.Fatalf("Merge(%v) = %d, want %d", other, got, want)
+				}
+			case 5:
+				other := NewWith(rapid.SliceOfN(rapid.IntRange(-10, 10), 0, 12).Draw(t, "Keep")...)
+				if got, want := s.Keep(other), model.Keep(other); got != want {
+					t.Fatalf("Keep(%v) = %d, want %d", other, got, want)
+				}
+			}
+
+			promoted = promoted || model.Cardinality() > threshold
+			if s.Promoted() != promoted {
+				t.Fatalf("Promoted() = %v with %d elements and threshold %d, want %v", s.Promoted(), s.Cardinality(),
+					threshold, promoted)
+			}
+			if !Equal[int](s, model) {
+				t.Fatalf("set = %v, want %v", s, model)
+			}
+			c := s.Clone().(*Hybrid[int])
+			if c.Promoted() != s.Promoted() || c.Threshold() != threshold || !Equal[int](c, model) {
+				t.Fatalf("Clone() = %v (promoted %v, threshold %d), want a copy of %v", c, c.Promoted(), c.Threshold(),
+					s)
+			}
+		}
+	})
+}
+
+func TestHybrid_ZeroValueAndNewEmpty(t *testing.T) {
+	t.Parallel()
+
+	var s Hybrid[int]
+	if s.Cardinality() != 0 || s.Contains(1) || s.Threshold() != DefaultHybridThreshold {
+		t.Fatal("zero value Hybrid is not empty with the default threshold")
+	}
+	AppendSeq[int](&s, slices.Values(genInts(DefaultHybridThreshold)))
+	if s.Promoted() {
+		t.Fatalf("promoted at %d elements", s.Cardinality())
+	}
+	s.Add(DefaultHybridThreshold)
+	if !s.Promoted() {
+		t.Fatalf("not promoted at %d elements", s.Cardinality())
+	}
+	if got := s.Drain(); len(got) != DefaultHybridThreshold+1 || s.Promoted() || s.Cardinality() != 0 {
+		t.Fatalf("Drain() = %v, leaving %v (promoted %v)", got, &s, s.Promoted())
+	}
+
+	e := NewHybridThreshold[int](2).NewEmpty().(*Hybrid[int])
+	if e.Threshold() != 2 || e.Promoted() {
+		t.Fatalf("NewEmpty() has threshold %d, promoted %v", e.Threshold(), e.Promoted())
+	}
+	if got, want := NewHybridWith(1, 2).String(), "Hybrid[int]([1 2])"; got != want {
+		t.Fatalf("String() = %q, want %q", got, want)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("NewHybridThreshold(-1) did not panic")
+		}
+	}()
+	NewHybridThreshold[int](-1)
+}
+
+func TestHybrid_JSON(t *testing.T) {
+	t.Parallel()
+
+	s := NewHybridWith(genInts(DefaultHybridThreshold + 4)...)
+	j, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var c *Hybrid[int]
+	if err := json.Unmarshal(j, &c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !Equal[int](s, c) || !c.Promoted() {
+		t.Fatalf("round trip = %v (promoted %v), want %v", c, c.Promoted(), s)
+	}
+
+	if err := c.UnmarshalJSON([]byte(`["a"]`)); err == nil {
+		t.Fatal("expected error unmarshaling mismatched element type")
+	}
+	if c.Cardinality() != DefaultHybridThreshold+4 {
+		t.Fatalf("set changed after failed unmarshal: %v", c)
+	}
+	if err := c.Scan(`[4,4]`); err != nil || !Equal[int](c, NewWith(4)) || c.Promoted() {
+		t.Fatalf("Scan = %v, set %v (promoted %v)", err, c, c.Promoted())
+	}
+	if v, err := NewHybrid[int]().Value(); err != nil || string(v.([]byte)) != "[]" {
+		t.Fatalf("empty Value() = %s, %v", v, err)
+	}
+}
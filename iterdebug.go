@@ -0,0 +1,14 @@
+package sets
+
+// Building with the setsdebug build tag (go test -tags setsdebug ./...) turns on checks that make the iterators of Map
+// and Ordered panic if the set is modified while it is being iterated, e.g. by calling Add or Remove from within a
+// range loop over the set. The Set interface leaves such modification undefined, and without the checks it silently
+// skips or repeats elements. Each set then counts its modifications, and the iterators compare the count after each
+// element they yield. Without the tag iterationChecks is a false constant, so the checks compile away.
+
+// checkIteration panics if a set's modification count has changed from start, the count when iteration began.
+func checkIteration(start, now uint64) {
+	if start != now {
+		panic("sets: set modified during iteration")
+	}
+}
@@ -0,0 +1,6 @@
+//go:build !setsdebug
+
+package sets
+
+// iterationChecks is false unless built with the setsdebug tag; see iterdebug.go.
+const iterationChecks = false
@@ -0,0 +1,6 @@
+//go:build setsdebug
+
+package sets
+
+// iterationChecks is true when built with the setsdebug tag; see iterdebug.go.
+const iterationChecks = true
@@ -0,0 +1,116 @@
+package sets
+
+import (
+	"testing"
+)
+
+// TestIterationChecks modifies sets from within their iterators, which must panic when built with the setsdebug tag.
+// Run it with go test -tags setsdebug.
+func TestIterationChecks(t *testing.T) {
+	t.Parallel()
+	if !iterationChecks {
+		t.Skip("iteration checks need the setsdebug build tag")
+	}
+
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{"Map.Iterator Add", func() {
+			s := NewWith(1, 2, 3)
+			for range s.Iterator {
+				s.Add(4)
+			}
+		}},
+		{"Map.Iterator Remove", func() {
+			s := NewWith(1, 2, 3)
+			for k := range s.Iterator {
+				s.Remove(k)
+			}
+		}},
+		{"Map.Iterator Clear", func() {
+			s := NewWith(1, 2, 3)
+			for range s.Iterator {
+				s.Clear()
+			}
+		}},
+		{"Ordered.Iterator Add", func() {
+			s := NewOrderedWith(1, 2, 3)
+			for k := range s.Iterator {
+				s.Add(k + 10)
+			}
+		}},
+		{"Ordered.Ordered Remove", func() {
+			s := NewOrderedWith(1, 2, 3)
+			for _, k := range s.Ordered {
+				s.Remove(k)
+			}
+		}},
+		{"Ordered.Backwards Sort", func() {
+			s := NewOrderedWith(3, 1, 2)
+			for range s.Backwards {
+				s.Sort()
+			}
+		}},
+		{"Ordered.Iterator MoveToFront", func() {
+			s := NewOrderedWith(1, 2, 3)
+			for k := range s.Iterator {
+				s.MoveToFront(k)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		got := func() (r any) {
+			defer func() { r = recover() }()
+			tt.fn()
+			return nil
+		}()
+		if got != "sets: set modified during iteration" {
+			t.Errorf("%s: recovered %v, want the modified during iteration panic", tt.name, got)
+		}
+	}
+}
+
+// TestIterationChecksDisabled checks that without the setsdebug tag a Map modified during iteration does not panic:
+// the checks, and their cost, are compiled out.
+func TestIterationChecksDisabled(t *testing.T) {
+	t.Parallel()
+	if iterationChecks {
+		t.Skip("iteration checks are enabled by the setsdebug build tag")
+	}
+
+	s := NewWith(1, 2, 3)
+	for k := range s.Iterator {
+		s.Remove(k)
+	}
+	if s.Cardinality() != 0 || s.mods != 0 {
+		t.Fatalf("set %v, modification count %d; want an empty set and no counting", s, s.mods)
+	}
+}
+
+// TestIterationChecksAllowed checks that operations which do not modify the set never trip the setsdebug checks.
+func TestIterationChecksAllowed(t *testing.T) {
+	t.Parallel()
+
+	m, o := NewWith(1, 2, 3), NewOrderedWith(1, 2, 3)
+	for k := range m.Iterator {
+		m.Add(k)     // already present
+		m.Remove(10) // not present
+		m.Contains(k)
+		for range m.Iterator { // nested read-only iteration
+		}
+	}
+	for k := range o.Iterator {
+		o.Add(k)
+		o.Remove(10)
+		o.MoveToBack(3) // already last
+	}
+	// modifying after iteration has stopped is fine
+	for k := range o.Iterator {
+		o.Remove(k)
+		break
+	}
+	if m.Cardinality() != 3 || o.Cardinality() != 2 {
+		t.Fatalf("unexpected contents: %v %v", m, o)
+	}
+}
@@ -0,0 +1,195 @@
+package sets
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// DecodeJSON reads a JSON array from the reader and adds each of its elements to the set, returning the number of
+// elements added. Unlike UnmarshalJSON, which decodes the whole array into a slice first, it decodes and adds one
+// element at a time, so memory use does not grow with the length of the array. A JSON null is treated as an empty
+// array. The set is not cleared first.
+//
+// It returns an error if the input is not a JSON array or an element cannot be decoded into K; elements decoded
+// before the error remain in the set, and the returned count includes them. DecodeJSON returns as soon as the array is
+// closed; any input after it is ignored, though, as with json.Decoder, some of it may have been buffered.
+func DecodeJSON[K comparable](r io.Reader, s Set[K]) (int, error) {
+	dec := json.NewDecoder(r)
+	tok, err := dec.Token()
+	if err != nil {
+		return 0, fmt.Errorf("decoding set: %w", err)
+	}
+	if tok == nil { // null
+		return 0, nil
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '[' {
+		return 0, fmt.Errorf("decoding set: expected a JSON array, got %v", tok)
+	}
+
+	var n int
+	for i := 0; dec.More(); i++ {
+		var k K
+		if err := dec.Decode(&k); err != nil {
+			return n, fmt.Errorf("decoding set element %d: %w", i, err)
+		}
+		if s.Add(k) {
+			n++
+		}
+	}
+	if _, err := dec.Token(); err != nil { // the closing ']'
+		return n, fmt.Errorf("decoding set: %w", err)
+	}
+	return n, nil
+}
+
+// MergeJSON unmarshals a JSON array and adds its elements to the set, returning the number added. Unlike UnmarshalJSON,
+// it does not clear the set first, so a set can be accumulated from several JSON documents, e.g. sharded blobs;
+// elements repeated across documents are added once, as by Add. A JSON null adds nothing.
+//
+// The whole array is decoded before any element is added, so if d is not a JSON array of K the set is left unchanged.
+// Use DecodeJSON instead to stream a large array from an io.Reader without holding it in memory.
+func MergeJSON[K comparable](s Set[K], d []byte) (int, error) {
+	var um []K
+	if err := json.Unmarshal(d, &um); err != nil {
+		return 0, fmt.Errorf("merging set: %w", err)
+	}
+	return AppendSeq(s, slices.Values(um)), nil
+}
+
+// EncodeJSON writes the set to the writer as a JSON array, encoding one element at a time instead of first collecting
+// the elements into a slice as MarshalJSON does, so memory use does not grow with the size of the set. Ordered sets
+// are written in order. The output is the same as MarshalJSON's for every set type except Bag, whose elements are
+// written once each, as its Iterator yields them, rather than once per occurrence, and PrioritySet, whose elements are
+// written without their priorities.
+//
+// If an element cannot be encoded, EncodeJSON stops and returns the error; the output written up to that point is
+// not a valid JSON document.
+func EncodeJSON[K comparable](w io.Writer, s Set[K]) error {
+	bw := bufio.NewWriter(w)
+	if err := bw.WriteByte('['); err != nil {
+		return fmt.Errorf("encoding set: %w", err)
+	}
+	var i int
+	var err error
+	s.Iterator(func(k K) bool {
+		var d []byte
+		if d, err = json.Marshal(k); err != nil {
+			err = fmt.Errorf("encoding set element %d: %w", i, err)
+			return false
+		}
+		if i > 0 {
+			bw.WriteByte(',')
+		}
+		_, err = bw.Write(d) // bufio.Writer errors are sticky, so checking this write covers the comma too
+		i++
+		return err == nil
+	})
+	if err != nil {
+		return err
+	}
+	bw.WriteByte(']')
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("encoding set: %w", err)
+	}
+	return nil
+}
+
+// MarshalJSONSorted marshals the set into a JSON array of its elements in ascending order, so equal sets always produce
+// identical output whatever their iteration order. Use it instead of MarshalJSON on unordered sets, such as Map and
+// SyncMap, when the serialized form must be reproducible, e.g. as a cache key. A SyncMap's elements are collected in a
+// single pass with Snapshot. If the set is empty or nil an empty JSON array is returned.
+func MarshalJSONSorted[K cmp.Ordered](s Set[K]) ([]byte, error) {
+	var elems []K
+	if sm, ok := s.(*SyncMap[K]); ok {
+		elems = sm.Snapshot()
+		slices.Sort(elems)
+	} else {
+		elems = SortedSlice(orEmpty(s))
+	}
+	if len(elems) == 0 {
+		return []byte("[]"), nil
+	}
+	d, err := json.Marshal(elems)
+	if err != nil {
+		return d, fmt.Errorf("marshaling sorted set: %w", err)
+	}
+	return d, nil
+}
+
+// SetField holds a Set[M] in a struct field that must round trip through JSON. encoding/json cannot unmarshal into a
+// nil interface, as it cannot know which set type to make, so a Set[M] field only unmarshals if it already holds a
+// set; SetField makes a *Map[M] when it holds none. Its methods are the set's, promoted from the embedded Set[M], and
+// any set with json.Marshaler and json.Unmarshaler methods, as every set in this package has, may be assigned to it:
+//
+//	type Doc struct {
+//		IDs  sets.SetField[int]           // a *Map[int] once unmarshaled, unless set beforehand
+//		Tags sets.OrderedSetField[string] // an *Ordered[string] once unmarshaled, unless set beforehand
+//	}
+//
+// A nil SetField marshals as an empty JSON array, as the package-level functions treat a nil set as empty. Declaring
+// the field with a concrete type, e.g. *sets.Map[int], works as well when the type is fixed.
+type SetField[M comparable] struct {
+	Set[M]
+}
+
+// MarshalJSON implements json.Marshaler by marshaling the held set.
+func (f SetField[M]) MarshalJSON() ([]byte, error) {
+	return marshalField[M](f.Set)
+}
+
+// UnmarshalJSON implements json.Unmarshaler by unmarshaling into the held set, first making a *Map[M] if it holds none.
+func (f *SetField[M]) UnmarshalJSON(d []byte) error {
+	if f.Set == nil {
+		f.Set = New[M]()
+	}
+	return unmarshalField(f.Set, d)
+}
+
+// OrderedSetField is SetField for an OrderedSet[M]: it makes an *Ordered[M] when it holds no set, so the order of the
+// JSON array is kept.
+type OrderedSetField[M cmp.Ordered] struct {
+	OrderedSet[M]
+}
+
+// MarshalJSON implements json.Marshaler by marshaling the held set.
+func (f OrderedSetField[M]) MarshalJSON() ([]byte, error) {
+	var s Set[M]
+	if f.OrderedSet != nil {
+		s = f.OrderedSet
+	}
+	return marshalField(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler by unmarshaling into the held set, first making an *Ordered[M] if it holds
+// none.
+func (f *OrderedSetField[M]) UnmarshalJSON(d []byte) error {
+	if f.OrderedSet == nil {
+		f.OrderedSet = NewOrdered[M]()
+	}
+	return unmarshalField[M](f.OrderedSet, d)
+}
+
+// marshalField marshals a SetField's set, or an empty JSON array for a nil one.
+func marshalField[M comparable](s Set[M]) ([]byte, error) {
+	if s == nil {
+		return []byte("[]"), nil
+	}
+	m, ok := s.(json.Marshaler)
+	if !ok {
+		return nil, fmt.Errorf("marshaling set field: %T does not implement json.Marshaler", s)
+	}
+	return m.MarshalJSON()
+}
+
+// unmarshalField unmarshals into a SetField's set.
+func unmarshalField[M comparable](s Set[M], d []byte) error {
+	u, ok := s.(json.Unmarshaler)
+	if !ok {
+		return fmt.Errorf("unmarshaling set field: %T does not implement json.Unmarshaler", s)
+	}
+	return u.UnmarshalJSON(d)
+}
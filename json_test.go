@@ -0,0 +1,328 @@
+package sets
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDecodeJSON(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		in      string
+		want    []int
+		n       int
+		wantErr bool
+	}{
+		{in: `[]`, want: []int{}},
+		{in: `null`, want: []int{}},
+		{in: ` [3, 1, 3, 2] `, want: []int{3, 1, 2}, n: 3},
+		{in: `[1, 2, "x", 4]`, want: []int{1, 2}, n: 2, wantErr: true},
+		{in: `[1, 2`, want: []int{1, 2}, n: 2, wantErr: true},
+		{in: `{"a": 1}`, want: []int{}, wantErr: true},
+		{in: `7`, want: []int{}, wantErr: true},
+		{in: ``, want: []int{}, wantErr: true},
+	} {
+		s := NewOrdered[int]()
+		n, err := DecodeJSON[int](strings.NewReader(tc.in), s)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("DecodeJSON(%q) error = %v, wantErr %v", tc.in, err, tc.wantErr)
+		}
+		if n != tc.n {
+			t.Errorf("DecodeJSON(%q) = %d, want %d", tc.in, n, tc.n)
+		}
+		if got := Elements(s); !slices.Equal(got, tc.want) {
+			t.Errorf("DecodeJSON(%q) elements = %v, want %v", tc.in, got, tc.want)
+		}
+	}
+
+	if _, err := DecodeJSON[int](strings.NewReader(``), New[int]()); !errors.Is(err, io.EOF) {
+		t.Errorf("DecodeJSON of empty input error = %v, want io.EOF", err)
+	}
+}
+
+func TestMergeJSON(t *testing.T) {
+	t.Parallel()
+
+	s := NewOrderedWith(1)
+	for _, tc := range []struct {
+		in      string
+		n       int
+		want    []int
+		wantErr bool
+	}{
+		{in: `[3, 1, 3, 2]`, n: 2, want: []int{1, 3, 2}},
+		{in: `null`, want: []int{1, 3, 2}},
+		{in: `[2, 4]`, n: 1, want: []int{1, 3, 2, 4}},
+		{in: `[5, "x"]`, want: []int{1, 3, 2, 4}, wantErr: true},
+		{in: `[5] [6]`, want: []int{1, 3, 2, 4}, wantErr: true},
+	} {
+		n, err := MergeJSON[int](s, []byte(tc.in))
+		if (err != nil) != tc.wantErr {
+			t.Errorf("MergeJSON(%q) error = %v, wantErr %v", tc.in, err, tc.wantErr)
+		}
+		if n != tc.n {
+			t.Errorf("MergeJSON(%q) = %d, want %d", tc.in, n, tc.n)
+		}
+		if got := Elements(s); !slices.Equal(got, tc.want) {
+			t.Errorf("MergeJSON(%q) elements = %v, want %v", tc.in, got, tc.want)
+		}
+	}
+}
+
+// TestDecodeJSON_Streams checks that DecodeJSON returns once the array is closed, without waiting for further input.
+func TestDecodeJSON_Streams(t *testing.T) {
+	t.Parallel()
+
+	s := New[string]()
+	n, err := DecodeJSON[string](io.MultiReader(strings.NewReader(`["a","b"]`), errReader{}), s)
+	if err != nil || n != 2 {
+		t.Fatalf("DecodeJSON = %d, %v, want 2, nil", n, err)
+	}
+}
+
+// errReader fails every read, so a test can detect reading beyond the expected input.
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) { return 0, errors.New("read past the end of the array") }
+
+func TestEncodeJSON(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range []Set[string]{
+		New[string](),
+		NewWith("<a>"),
+		NewOrderedWith("c", "a", "b"),
+		NewSortedSetWith("c", "a", "b"),
+		NewLockedOrderedWith("x", "y"),
+		NewBounded[string](2),
+	} {
+		var buf bytes.Buffer
+		if err := EncodeJSON(&buf, s); err != nil {
+			t.Fatalf("EncodeJSON(%v) error: %v", s, err)
+		}
+		want, err := json.Marshal(s)
+		if err != nil {
+			t.Fatalf("json.Marshal(%v) error: %v", s, err)
+		}
+		if buf.String() != string(want) {
+			t.Errorf("EncodeJSON(%v) = %s, want %s", s, buf.String(), want)
+		}
+	}
+
+	// unordered sets: compare as sets
+	s := NewWith(5, 1, 4, 2, 3)
+	var buf bytes.Buffer
+	if err := EncodeJSON[int](&buf, s); err != nil {
+		t.Fatalf("EncodeJSON error: %v", err)
+	}
+	var got []int
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("EncodeJSON output %s is not a JSON array: %v", buf.String(), err)
+	}
+	if !Equal[int](NewWith(got...), s) {
+		t.Errorf("EncodeJSON(%v) = %s", s, buf.String())
+	}
+}
+
+func TestEncodeJSON_Errors(t *testing.T) {
+	t.Parallel()
+
+	if err := EncodeJSON(io.Discard, NewOrderedWith(1, math.NaN())); err == nil {
+		t.Error("EncodeJSON of a set holding NaN did not error")
+	}
+	if err := EncodeJSON(errWriter{}, NewWith(1)); err == nil {
+		t.Error("EncodeJSON to a failing writer did not error")
+	}
+}
+
+// errWriter fails every write.
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) { return 0, errors.New("write failed") }
+
+func TestMarshalJSONSorted(t *testing.T) {
+	t.Parallel()
+
+	elems := []int{5, 1, 4, 2, 3}
+	for _, s := range []Set[int]{
+		NewWith(elems...),
+		NewSyncMapWith(elems...),
+		NewLockedWith(elems...),
+		NewOrderedWith(elems...),
+		NewSortedSetWith(elems...),
+	} {
+		d, err := MarshalJSONSorted(s)
+		if err != nil {
+			t.Fatalf("MarshalJSONSorted(%v) error: %v", s, err)
+		}
+		if string(d) != "[1,2,3,4,5]" {
+			t.Errorf("MarshalJSONSorted(%v) = %s, want [1,2,3,4,5]", s, d)
+		}
+	}
+	for _, s := range []Set[int]{nil, New[int](), NewSyncMap[int]()} {
+		if d, err := MarshalJSONSorted(s); err != nil || string(d) != "[]" {
+			t.Errorf("MarshalJSONSorted(%v) = %s, %v, want [], nil", s, d, err)
+		}
+	}
+	if _, err := MarshalJSONSorted[float64](NewWith(1, math.NaN())); err == nil {
+		t.Error("MarshalJSONSorted of a set holding NaN did not error")
+	}
+}
+
+// TestUnmarshalJSON_Null checks that unmarshaling a JSON null empties every mutable set type without an error, as
+// Scan(nil) does, while a Frozen set reports ErrFrozen and keeps its elements.
+func TestUnmarshalJSON_Null(t *testing.T) {
+	t.Parallel()
+
+	sets := map[string]Set[int]{"Observable": NewObservable[int](NewWith(1, 2, 3))}
+	for name, newSet := range intSetConstructors {
+		sets[name] = newSet(1, 2, 3)
+	}
+	for name, s := range sets {
+		err := s.(json.Unmarshaler).UnmarshalJSON([]byte("null"))
+		if _, frozen := s.(*Frozen[int]); frozen {
+			if !errors.Is(err, ErrFrozen) || s.Cardinality() != 3 {
+				t.Errorf("%s: UnmarshalJSON(null) = %v, left %v", name, err, s)
+			}
+			continue
+		}
+		if err != nil || s.Cardinality() != 0 {
+			t.Errorf("%s: UnmarshalJSON(null) = %v, left %v, want an empty set", name, err, s)
+		}
+	}
+
+	// through encoding/json, a null set-valued field is emptied, while a null pointer field is set to nil without
+	// calling UnmarshalJSON, as for any pointer
+	var v struct {
+		S BitSet[int] // a zero value BitSet is ready to use
+		P *Ordered[int]
+	}
+	v.S.Add(1)
+	v.P = NewOrderedWith(1, 2)
+	p := v.P
+	if err := json.Unmarshal([]byte(`{"S": null, "P": null}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.S.Cardinality() != 0 {
+		t.Errorf("json.Unmarshal of a null set field left %v", &v.S)
+	}
+	if v.P != nil || p.Cardinality() != 2 {
+		t.Errorf("json.Unmarshal of a null pointer field: field = %v, old set = %v", v.P, p)
+	}
+}
+
+func TestOrdered_JSONObject(t *testing.T) {
+	t.Parallel()
+
+	s := NewOrderedWith("low", "medium", `"high"`)
+	d, err := s.MarshalJSONObject()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := string(d), `{"low":0,"medium":1,"\"high\"":2}`; got != want {
+		t.Fatalf("MarshalJSONObject() = %s, want %s", got, want)
+	}
+	c := NewOrdered[string]()
+	if err := c.UnmarshalJSONObject([]byte(`{"medium":1,"\"high\"":2,"low":0}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !EqualOrdered[string](c, s) {
+		t.Fatalf("round trip = %v, want %v", c, s)
+	}
+
+	f := NewOrderedWith(2.5, -1.0, 3.0)
+	d, err = f.MarshalJSONObject()
+	if err != nil || string(d) != `{"2.5":0,"-1":1,"3":2}` {
+		t.Fatalf("MarshalJSONObject() = %s, %v", d, err)
+	}
+	g := NewOrdered[float64]()
+	if err := g.UnmarshalJSONObject(d); err != nil || !EqualOrdered[float64](g, f) {
+		t.Fatalf("round trip = %v, %v, want %v", g, err, f)
+	}
+	if d, err := NewOrdered[int]().MarshalJSONObject(); err != nil || string(d) != "{}" {
+		t.Fatalf("empty MarshalJSONObject() = %s, %v", d, err)
+	}
+	if _, err := NewOrderedWith(math.NaN()).MarshalJSONObject(); err == nil {
+		t.Fatal("expected error marshaling NaN")
+	}
+
+	for _, bad := range []string{`[1]`, `{"1":1}`, `{"1":0,"2":0}`, `{"x":0}`, `{"1":0,"1.0":1}`} {
+		if err := g.UnmarshalJSONObject([]byte(bad)); err == nil {
+			t.Errorf("UnmarshalJSONObject(%s) succeeded, want an error", bad)
+		}
+		if !EqualOrdered[float64](g, f) {
+			t.Fatalf("set changed after failed UnmarshalJSONObject(%s): %v", bad, g)
+		}
+	}
+	if err := g.UnmarshalJSONObject([]byte("null")); err != nil || g.Cardinality() != 0 {
+		t.Fatalf("UnmarshalJSONObject(null) = %v, left %v", err, g)
+	}
+}
+
+// TestJSON_StructFields round-trips a struct holding sets of different types: interface-typed fields unmarshal only
+// into the sets they already hold, while SetField and OrderedSetField make sets of their own.
+func TestJSON_StructFields(t *testing.T) {
+	t.Parallel()
+
+	type doc struct {
+		IDs  Set[int]
+		Tags OrderedSet[string]
+	}
+	in := doc{IDs: NewWith(3, 1, 2), Tags: NewOrderedWith("b", "a", "c")}
+	d, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// interface fields holding sets unmarshal into them, keeping their types
+	out := doc{IDs: NewSortedSet[int](), Tags: NewOrdered[string]()}
+	if err := json.Unmarshal(d, &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := out.IDs.(*SortedSet[int]); !ok || !Equal(out.IDs, in.IDs) || !EqualOrdered(out.Tags, in.Tags) {
+		t.Fatalf("round trip = %+v, want %+v", out, in)
+	}
+	// nil interface fields cannot be unmarshaled: there is no telling which set type to make
+	var empty doc
+	if err := json.Unmarshal(d, &empty); err == nil {
+		t.Fatal("unmarshaling into nil interface fields succeeded, want an error")
+	}
+
+	type fieldDoc struct {
+		IDs  SetField[int]
+		Tags OrderedSetField[string]
+		None SetField[int]
+	}
+	var fd fieldDoc
+	if err := json.Unmarshal(d, &fd); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := fd.IDs.Set.(*Map[int]); !ok || !Equal(fd.IDs.Set, in.IDs) {
+		t.Fatalf("SetField = %v, want a *Map[int] of %v", fd.IDs.Set, in.IDs)
+	}
+	if _, ok := fd.Tags.OrderedSet.(*Ordered[string]); !ok || !EqualOrdered(fd.Tags.OrderedSet, in.Tags) {
+		t.Fatalf("OrderedSetField = %v, want an *Ordered[string] of %v", fd.Tags.OrderedSet, in.Tags)
+	}
+	if fd.None.Set != nil {
+		t.Fatalf("absent SetField = %v, want nil", fd.None.Set)
+	}
+
+	fd.IDs = SetField[int]{NewBitSetWith(7)} // a field may hold any set type
+	d, err = json.Marshal(fd)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := `{"IDs":[7],"Tags":["b","a","c"],"None":[]}`; string(d) != want {
+		t.Fatalf("Marshal = %s, want %s", d, want)
+	}
+	if err := json.Unmarshal([]byte(`{"IDs":null}`), &fd); err != nil || fd.IDs.Cardinality() != 0 {
+		t.Fatalf("unmarshaling null = %v, left %v", err, fd.IDs.Set)
+	}
+}
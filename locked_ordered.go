@@ -16,6 +16,10 @@ import (
 // Intersectioner, Differencer, SymmetricDifferencer, Equaler, Disjointer, Subsetter, Maxer, and
 // Minner) to the inner set under the read lock, exactly as Locked does — see Locked for the
 // locking rules that make the delegation deadlock-free.
+//
+// Iterator, Ordered, and Backwards iterate over a copy of the elements taken under the read lock, exactly as Locked's
+// Iterator does: writers only wait for the copy to be taken, never for an iteration to finish, and an iteration sees
+// the set as it was when it started.
 type LockedOrdered[M cmp.Ordered] struct {
 	set OrderedSet[M]
 	sync.RWMutex
@@ -33,6 +37,8 @@ var _ Subsetter[int] = new(LockedOrdered[int])
 var _ Maxer[int] = new(LockedOrdered[int])
 var _ Minner[int] = new(LockedOrdered[int])
 var _ tryUnwrapper[int] = new(LockedOrdered[int])
+var _ stableSorter[int] = new(LockedOrdered[int])
+var _ sortTracker = new(LockedOrdered[int])
 
 // NewLockedOrdered returns an empty *LockedOrdered[M] instance that is safe for concurrent use.
 func NewLockedOrdered[M cmp.Ordered]() *LockedOrdered[M] {
@@ -67,18 +73,33 @@ func NewLockedOrderedWrapping[M cmp.Ordered](set OrderedSet[M]) OrderedSet[M] {
 
 // Contains returns true if the set contains the element.
 func (s *LockedOrdered[M]) Contains(m M) bool {
+	if s == nil {
+		return false
+	}
 	s.RLock()
 	defer s.RUnlock()
 	return s.set.Contains(m)
 }
 
-// Clear the set and returns the number of elements removed.
+// Clear the set and returns the number of elements removed. Use Drain instead to also get the removed elements, in
+// their prior order.
 func (s *LockedOrdered[M]) Clear() int {
 	s.Lock()
 	defer s.Unlock()
 	return s.set.Clear()
 }
 
+// Drain removes all elements from the set and returns them in order, under a single write lock, so no other goroutine
+// can observe or modify the set part way through. Returns nil if the set is empty.
+func (s *LockedOrdered[M]) Drain() []M {
+	s.Lock()
+	defer s.Unlock()
+	if s.set == nil { // zero value
+		return nil
+	}
+	return Drain[M](s.set)
+}
+
 // Add an element to the set. Returns true if the element was added, false if it was already present.
 func (s *LockedOrdered[M]) Add(m M) bool {
 	s.Lock()
@@ -93,6 +114,126 @@ func (s *LockedOrdered[M]) Remove(m M) bool {
 	return s.set.Remove(m)
 }
 
+// AddAll adds all of the items to the set under a single acquisition of the write lock, and returns the number of
+// items that were not already present. Unlike AppendSeq, which locks once per element, readers observe either none or
+// all of the items.
+func (s *LockedOrdered[M]) AddAll(items ...M) int {
+	s.Lock()
+	defer s.Unlock()
+
+	var n int
+	for _, m := range items {
+		if s.set.Add(m) {
+			n++
+		}
+	}
+	return n
+}
+
+// Merge adds all of other's elements to the set under a single acquisition of the write lock and returns the number
+// that were not already present, so readers observe either none or all of them. Elements new to the set are appended in
+// other's iteration order. other's elements are collected before the lock is taken, so merging two locked sets into
+// each other concurrently cannot deadlock.
+func (s *LockedOrdered[M]) Merge(other Set[M]) int {
+	elems := Elements(orEmpty(other))
+	return s.AddAll(elems...)
+}
+
+// Keep removes every element that is not in other from the set under a single acquisition of the write lock and
+// returns the number removed. Like Merge, it copies other's elements before the lock is taken, so keeping two locked
+// sets in step with each other concurrently cannot deadlock.
+func (s *LockedOrdered[M]) Keep(other Set[M]) int {
+	if Set[M](s) == other {
+		return 0
+	}
+	keep := NewFrom(orEmpty(other).Iterator)
+
+	s.Lock()
+	defer s.Unlock()
+	return keepOnly[M](s.set, keep)
+}
+
+// RemoveAll removes all of other's elements from the set under a single acquisition of the write lock and returns the
+// number removed, so readers observe either none or all of the removals. It is the in-place form of Difference. other
+// is walked under the lock rather than copied, unless it is itself a locked set: its elements are collected before the
+// lock is taken, as by Merge and Keep, so two locked sets removing each other's elements concurrently cannot deadlock.
+func (s *LockedOrdered[M]) RemoveAll(other Set[M]) int {
+	if Set[M](s) == other {
+		return s.Clear()
+	}
+	other = orEmpty(other)
+	seq := iter.Seq[M](other.Iterator)
+	if _, ok := other.(Locker); ok {
+		seq = slices.Values(Elements(other))
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	if s.set == nil { // zero value
+		return 0
+	}
+	return RemoveSeq(s.set, seq)
+}
+
+// ContainsEach reports, for each item, whether it is in the set, checking all of them under a single acquisition of the
+// read lock: out[i] is true if items[i] is in the set. Unlike calling Contains per item, which locks once each, the
+// results all reflect the set at a single moment.
+func (s *LockedOrdered[M]) ContainsEach(items []M) []bool {
+	out := make([]bool, len(items))
+	if s == nil {
+		return out
+	}
+	s.RLock()
+	defer s.RUnlock()
+	if s.set == nil { // zero value
+		return out
+	}
+	for i, m := range items {
+		out[i] = s.set.Contains(m)
+	}
+	return out
+}
+
+// RemoveEach removes all of the items from the set under a single acquisition of the write lock, and returns the
+// number of items that were present. Like AddAll, it is atomic with respect to readers.
+func (s *LockedOrdered[M]) RemoveEach(items ...M) int {
+	s.Lock()
+	defer s.Unlock()
+
+	var n int
+	for _, m := range items {
+		if s.set.Remove(m) {
+			n++
+		}
+	}
+	return n
+}
+
+// Checkpoint copies the elements under the write lock and returns a function that restores the set to them, e.g. to
+// roll back a batch of changes that failed part way through. The restore function clears the set and re-adds the copied
+// elements in their checkpointed order under a single acquisition of the write lock, so readers observe either the
+// changes or the checkpoint, never a mix. It may be called any number of times, and it keeps the copy alive until it is
+// itself garbage collected: a checkpoint costs memory proportional to the size of the set when it was taken.
+func (s *LockedOrdered[M]) Checkpoint() func() {
+	s.Lock()
+	defer s.Unlock()
+	if s.set == nil { // zero value
+		s.set = NewOrdered[M]()
+	}
+	elems := make([]M, 0, s.set.Cardinality())
+	for v := range s.set.Iterator {
+		elems = append(elems, v)
+	}
+	return func() {
+		s.Lock()
+		defer s.Unlock()
+		s.set.Clear()
+		for _, m := range elems {
+			s.set.Add(m)
+		}
+	}
+}
+
 // Cardinality returns the number of elements in the set.
 func (s *LockedOrdered[M]) Cardinality() int {
 	if s == nil {
@@ -108,6 +249,9 @@ func (s *LockedOrdered[M]) Cardinality() int {
 func (s *LockedOrdered[M]) snapshot() []M {
 	s.RLock()
 	defer s.RUnlock()
+	if s.set == nil { // zero value
+		return nil
+	}
 	elems := make([]M, 0, s.set.Cardinality())
 	for v := range s.set.Iterator {
 		elems = append(elems, v)
@@ -119,6 +263,9 @@ func (s *LockedOrdered[M]) snapshot() []M {
 // iterates without holding the lock. This means it is safe to call any method on the set from within the yield
 // callback, but the iteration may not reflect concurrent modifications.
 func (s *LockedOrdered[M]) Iterator(yield func(M) bool) {
+	if s == nil {
+		return
+	}
 	for _, v := range s.snapshot() {
 		if !yield(v) {
 			return
@@ -126,8 +273,12 @@ func (s *LockedOrdered[M]) Iterator(yield func(M) bool) {
 	}
 }
 
-// Clone returns a new set of the same underlying type.
+// Clone returns a new *LockedOrdered wrapping a clone of the inner set, so the clone keeps the inner set's concrete
+// type and order (e.g. a wrapped SortedSet stays a SortedSet).
 func (s *LockedOrdered[M]) Clone() Set[M] {
+	if s == nil {
+		return NewLockedOrdered[M]()
+	}
 	s.RLock()
 	defer s.RUnlock()
 	if s.set == nil {
@@ -162,6 +313,9 @@ func (s *LockedOrdered[M]) Backwards(yield func(int, M) bool) {
 
 // NewEmptyOrdered returns a new empty ordered set of the same underlying type.
 func (s *LockedOrdered[M]) NewEmptyOrdered() OrderedSet[M] {
+	if s == nil {
+		return NewLockedOrdered[M]()
+	}
 	s.RLock()
 	defer s.RUnlock()
 	if s.set == nil {
@@ -191,6 +345,30 @@ func (s *LockedOrdered[M]) Sort() {
 	s.set.Sort()
 }
 
+// SortStable sorts the set in ascending order under the write lock, keeping the prior relative order of elements that
+// compare equal; see Ordered.SortStable. An inner set without a SortStable method is stably sorted as SortBy would.
+func (s *LockedOrdered[M]) SortStable() {
+	s.Lock()
+	defer s.Unlock()
+	if ss, ok := s.set.(interface{ SortStable() }); ok {
+		ss.SortStable()
+		return
+	}
+	sortStable(s.set, cmp.Compare[M])
+}
+
+// sortStableFunc stably sorts the inner set by cmp under the write lock, so SortBy on a LockedOrdered is atomic.
+//
+//lint:ignore U1000 reached via the stableSorter type assertion in sortStable
+func (s *LockedOrdered[M]) sortStableFunc(cmp func(M, M) int) {
+	s.Lock()
+	defer s.Unlock()
+	if s.set == nil { // zero value: nothing to sort
+		return
+	}
+	sortStable(s.set, cmp)
+}
+
 // At returns the element at the index. If the index is out of bounds, the second return value is false.
 func (s *LockedOrdered[M]) At(i int) (M, bool) {
 	s.RLock()
@@ -199,6 +377,52 @@ func (s *LockedOrdered[M]) At(i int) (M, bool) {
 	return s.set.At(i)
 }
 
+// AtMany returns the elements at the indexes, in the order requested, looking all of them up under a single
+// acquisition of the read lock, so they all reflect the set at a single moment. As in Ordered.AtMany, an out-of-bounds
+// index yields the zero value of M.
+func (s *LockedOrdered[M]) AtMany(indices ...int) []M {
+	out := make([]M, len(indices))
+	s.RLock()
+	defer s.RUnlock()
+	for i, j := range indices {
+		out[i], _ = s.set.At(j)
+	}
+	return out
+}
+
+// Head returns a new *LockedOrdered[M] holding the first n elements of the set, in order, in a new set of the inner
+// set's type, copied under the read lock. n is clamped as in Ordered.Head. The set is unchanged.
+func (s *LockedOrdered[M]) Head(n int) OrderedSet[M] {
+	s.RLock()
+	defer s.RUnlock()
+	c := s.set.NewEmptyOrdered()
+	for i, v := range s.set.Ordered {
+		if i >= n {
+			break
+		}
+		c.Add(v)
+	}
+	return &LockedOrdered[M]{set: c}
+}
+
+// Tail returns a new *LockedOrdered[M] holding the last n elements of the set, in order, in a new set of the inner
+// set's type, copied under the read lock. n is clamped as in Ordered.Head. The set is unchanged.
+func (s *LockedOrdered[M]) Tail(n int) OrderedSet[M] {
+	s.RLock()
+	defer s.RUnlock()
+	var tail []M
+	for _, v := range s.set.Backwards {
+		if len(tail) >= n {
+			break
+		}
+		tail = append(tail, v)
+	}
+	slices.Reverse(tail)
+	c := s.set.NewEmptyOrdered()
+	AppendSeq(c, slices.Values(tail))
+	return &LockedOrdered[M]{set: c}
+}
+
 // Index returns the index of the element in the set, or -1 if not present.
 func (s *LockedOrdered[M]) Index(m M) int {
 	s.RLock()
@@ -207,6 +431,114 @@ func (s *LockedOrdered[M]) Index(m M) int {
 	return s.set.Index(m)
 }
 
+// IndexFunc returns the index of the first element, in order, for which the function returns true, or -1 if there is
+// none. Like Iterator, it scans a snapshot of the elements taken under the read lock, so the function may call any
+// method on the set, and the index reflects the set as it was when the scan started.
+func (s *LockedOrdered[M]) IndexFunc(f func(M) bool) int {
+	if s == nil {
+		return -1
+	}
+	return slices.IndexFunc(s.snapshot(), f)
+}
+
+// AddSorted adds an element at its sorted position by delegating to the inner set's AddSorted (see Ordered.AddSorted)
+// under the write lock. Returns true if the element was added, false if it was already present. If the inner set has
+// no AddSorted method, e.g. a Bounded, the element is added with Add instead, and an inner set that was sorted before
+// is sorted again with Sort, so that it stays sorted, at a cost of O(N log N).
+func (s *LockedOrdered[M]) AddSorted(m M) bool {
+	s.Lock()
+	defer s.Unlock()
+	if as, ok := s.set.(interface{ AddSorted(M) bool }); ok {
+		return as.AddSorted(m)
+	}
+	sorted := IsSorted(s.set)
+	added := s.set.Add(m) // may reorder even when m is present: a Bounded moves it to the back
+	if sorted && !IsSorted(s.set) {
+		s.set.Sort()
+	}
+	return added
+}
+
+// Rotate cyclically shifts the elements n positions to the left (right for a negative n) by delegating to the inner
+// set's Rotate (see Ordered.Rotate) under the write lock. If the inner set has no Rotate method but can move elements,
+// e.g. a Bounded, the rotation is done by moving each element to the back in its new order, in O(N) moves. It does
+// nothing if the inner set's order cannot be changed at all (e.g. a SortedSet).
+func (s *LockedOrdered[M]) Rotate(n int) {
+	s.Lock()
+	defer s.Unlock()
+	if r, ok := s.set.(interface{ Rotate(int) }); ok {
+		r.Rotate(n)
+		return
+	}
+	el := Elements[M](s.set)
+	if len(el) == 0 {
+		return
+	}
+	n = (n%len(el) + len(el)) % len(el)
+	s.reorder(append(el[n:], el[:n]...))
+}
+
+// MoveToFront moves an element already in the set to the front of the order by delegating to the inner set's
+// MoveToFront (see Ordered.MoveToFront) under the write lock. Returns false if the element is not present, or if the
+// inner set's order cannot be changed (e.g. a SortedSet) and so it has no MoveToFront method.
+func (s *LockedOrdered[M]) MoveToFront(m M) bool {
+	s.Lock()
+	defer s.Unlock()
+	if mv, ok := s.set.(interface{ MoveToFront(M) bool }); ok {
+		return mv.MoveToFront(m)
+	}
+	return false
+}
+
+// MoveToBack moves an element already in the set to the back of the order by delegating to the inner set's
+// MoveToBack (see Ordered.MoveToBack) under the write lock; see MoveToFront for when it returns false.
+func (s *LockedOrdered[M]) MoveToBack(m M) bool {
+	s.Lock()
+	defer s.Unlock()
+	if mv, ok := s.set.(interface{ MoveToBack(M) bool }); ok {
+		return mv.MoveToBack(m)
+	}
+	return false
+}
+
+// Swap exchanges the elements at indexes i and j by delegating to the inner set's Swap (see Ordered.Swap) under the
+// write lock. If the inner set has no Swap method but can move elements, e.g. a Bounded, the swap is done as Rotate's
+// is, in O(N) moves. Returns false if either index is out of bounds, or if the inner set's order cannot be changed at
+// all (e.g. a SortedSet).
+func (s *LockedOrdered[M]) Swap(i, j int) bool {
+	s.Lock()
+	defer s.Unlock()
+	if sw, ok := s.set.(interface{ Swap(int, int) bool }); ok {
+		return sw.Swap(i, j)
+	}
+	el := Elements[M](s.set)
+	if i < 0 {
+		i += len(el)
+	}
+	if j < 0 {
+		j += len(el)
+	}
+	if i < 0 || i >= len(el) || j < 0 || j >= len(el) {
+		return false
+	}
+	el[i], el[j] = el[j], el[i]
+	return s.reorder(el)
+}
+
+// reorder puts the inner set's elements in the given order, a permutation of them, by moving each to the back in turn:
+// the fallback for inner sets that can move elements but have no Rotate or Swap method. Returns false, leaving the set
+// unchanged, if the inner set has no MoveToBack method either. The caller must hold the write lock.
+func (s *LockedOrdered[M]) reorder(order []M) bool {
+	mv, ok := s.set.(interface{ MoveToBack(M) bool })
+	if !ok {
+		return false
+	}
+	for _, m := range order {
+		mv.MoveToBack(m)
+	}
+	return true
+}
+
 //lint:ignore U1000 reached via the tryUnwrapper[M] type assertion in tryUnwrapOperand
 func (s *LockedOrdered[M]) tryUnwrap() (Set[M], func(), bool) {
 	if s == nil || !s.TryRLock() {
@@ -379,6 +711,19 @@ func (s *LockedOrdered[M]) Subset(other Set[M]) (bool, bool) {
 	return sub.Subset(operand)
 }
 
+// knownSorted reports, under the read lock, whether the inner set tracks its sorted state and is known to be sorted.
+//
+//lint:ignore U1000 reached via the sortTracker type assertion in IsSorted
+func (s *LockedOrdered[M]) knownSorted() bool {
+	if s == nil {
+		return true
+	}
+	s.RLock()
+	defer s.RUnlock()
+	st, ok := s.set.(sortTracker)
+	return ok && st.knownSorted()
+}
+
 // Max implements Maxer by delegating to the inner set's Maxer under the read lock. It declines
 // when the inner set doesn't implement Maxer or cannot answer, sending the package-level Max down
 // the generic path.
@@ -417,23 +762,29 @@ func (s *LockedOrdered[M]) String() string {
 	return "Locked" + s.set.String()
 }
 
+// stringPrefix returns the prefix of String: Locked followed by the inner set's prefix.
+//
+//lint:ignore U1000 reached via the stringPrefixer type assertion in stringPrefix
+func (s *LockedOrdered[M]) stringPrefix() string {
+	s.RLock()
+	defer s.RUnlock()
+	return "Locked" + stringPrefix(s.set)
+}
+
 // Value implements the driver.Valuer interface. It returns the JSON representation of the set.
 func (s *LockedOrdered[M]) Value() (driver.Value, error) {
 	return s.MarshalJSON()
 }
 
 // MarshalJSON implements json.Marshaler. It will marshal the set to JSON. It returns a JSON array of the elements in
-// the set. If the set is empty, it returns an empty JSON array.
+// the set. If the set is empty, it returns an empty JSON array. The inner set's own MarshalJSON is used when it has
+// one; otherwise its elements are marshaled in order as a plain JSON array, so a LockedOrdered wrapping any OrderedSet
+// can be serialized.
 func (s *LockedOrdered[M]) MarshalJSON() ([]byte, error) {
 	s.RLock()
 	defer s.RUnlock()
 
-	jm, ok := s.set.(json.Marshaler)
-	if !ok {
-		return nil, fmt.Errorf("cannot marshal set of type %T - not json.Marshaler", s.set)
-	}
-
-	d, err := jm.MarshalJSON()
+	d, err := marshalInner[M](s.set)
 	if err != nil {
 		return d, fmt.Errorf("marshaling locked ordered set: %w", err)
 	}
@@ -1,22 +1,28 @@
 package sets
 
 import (
+	"container/heap"
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"hash/maphash"
 	"iter"
 	"maps"
+	"math/rand/v2"
 	"slices"
 )
 
 // Map is the default set implementation based on top of go's map type. It is not ordered and does not guarantee
 // the order of elements when iterating over them. It is not safe for concurrent use.
 type Map[M comparable] struct {
-	set map[M]struct{}
+	set  map[M]struct{}
+	hw   int    // high-water mark of the elements the map has held or been sized for; see Cap
+	mods uint64 // modification count, maintained only with the setsdebug build tag; see iterdebug.go
 }
 
 var _ Set[int] = new(Map[int])
 var _ driver.Valuer = new(Map[int])
+var _ capacitySet = new(Map[int])
 
 // New returns an empty *Map[M] instance.
 func New[M comparable]() *Map[M] {
@@ -36,15 +42,102 @@ func NewFrom[M comparable](seq iter.Seq[M]) *Map[M] {
 
 // NewWith returns a new *Map[M] with the values provided.
 func NewWith[M comparable](m ...M) *Map[M] {
-	s := &Map[M]{set: make(map[M]struct{}, len(m))}
+	s := &Map[M]{set: make(map[M]struct{}, len(m)), hw: len(m)}
 	for _, x := range m {
 		s.set[x] = struct{}{}
 	}
 	return s
 }
 
+// NewFromString returns a new *Map[rune] containing the distinct runes of str. Invalid UTF-8 bytes are added as
+// utf8.RuneError, as when ranging over a string.
+func NewFromString(str string) *Map[rune] {
+	return NewFrom(runes(str))
+}
+
+// NewReservoir returns a new *Map[M] holding k distinct elements of seq chosen uniformly at random, for sampling a
+// stream too long to hold in memory: seq is consumed once and only the k elements chosen so far are kept. If seq has
+// fewer than k distinct elements, all of them are kept. It panics if k is less than 1.
+//
+// The sample is uniform over the distinct elements, not over the stream: each of the D distinct elements of seq is
+// kept with probability k/D however many times it occurs, and every k-element subset is equally likely. This is
+// bottom-k sampling: each element is given a priority by hashing it, so repeats of an element share its priority, and
+// the k elements with the lowest priorities are kept.
+//
+// The priorities are salted with a value drawn from r, or from the top-level math/rand/v2 functions if r is nil, so the
+// choice of sample is driven by r: within a process, two r seeded alike give the same sample of the same sequence, e.g.
+// for reproducible tests. Go offers no hash of comparable values that is stable across processes, so the same seed may
+// give a different sample in another run of the program.
+func NewReservoir[M comparable](seq iter.Seq[M], k int, r *rand.Rand) *Map[M] {
+	if k < 1 {
+		panic("sets.NewReservoir: k must be > 0")
+	}
+	salt := rand.Uint64()
+	if r != nil {
+		salt = r.Uint64()
+	}
+	res := &reservoir[M]{in: make(map[M]struct{}, k)}
+	for m := range seq {
+		if _, ok := res.in[m]; ok {
+			continue
+		}
+		p := mix64(maphash.Comparable(reservoirSeed, m) ^ salt)
+		if len(res.items) < k {
+			heap.Push(res, reservoirItem[M]{m, p})
+			res.in[m] = struct{}{}
+		} else if p < res.items[0].p {
+			delete(res.in, res.items[0].m)
+			res.items[0] = reservoirItem[M]{m, p}
+			res.in[m] = struct{}{}
+			heap.Fix(res, 0)
+		}
+	}
+	return &Map[M]{set: res.in, hw: k}
+}
+
+// reservoirSeed seeds NewReservoir's element hashes. It is fixed for the life of the process, so that the salt drawn
+// from the caller's *rand.Rand alone decides the sample.
+var reservoirSeed = maphash.MakeSeed()
+
+// reservoirItem is an element of a NewReservoir sample and its priority.
+type reservoirItem[M comparable] struct {
+	m M
+	p uint64
+}
+
+// reservoir is NewReservoir's sample: a max-heap on priority, so the element to evict next is at the root, and the set
+// of its elements.
+type reservoir[M comparable] struct {
+	items []reservoirItem[M]
+	in    map[M]struct{}
+}
+
+func (r *reservoir[M]) Len() int           { return len(r.items) }
+func (r *reservoir[M]) Less(i, j int) bool { return r.items[i].p > r.items[j].p }
+func (r *reservoir[M]) Swap(i, j int)      { r.items[i], r.items[j] = r.items[j], r.items[i] }
+func (r *reservoir[M]) Push(x any)         { r.items = append(r.items, x.(reservoirItem[M])) }
+func (r *reservoir[M]) Pop() any {
+	x := r.items[len(r.items)-1]
+	r.items = r.items[:len(r.items)-1]
+	return x
+}
+
+// runes of str, in order, as when ranging over the string.
+func runes(str string) iter.Seq[rune] {
+	return func(yield func(rune) bool) {
+		for _, r := range str {
+			if !yield(r) {
+				return
+			}
+		}
+	}
+}
+
 // Contains returns true if the set contains the element.
 func (s *Map[M]) Contains(m M) bool {
+	if s == nil {
+		return false
+	}
 	_, ok := s.set[m]
 	return ok
 }
@@ -58,15 +151,84 @@ func (s *Map[M]) Clear() int {
 	for k := range s.set {
 		delete(s.set, k)
 	}
+	s.modified()
 	return n
 }
 
+// modified records a modification of the set for the setsdebug iteration checks.
+func (s *Map[M]) modified() {
+	if iterationChecks {
+		s.mods++
+	}
+}
+
+// Drain removes all elements from the set and returns them. Returns nil if the set is empty.
+func (s *Map[M]) Drain() []M {
+	if len(s.set) == 0 {
+		return nil
+	}
+	out := slices.Collect(maps.Keys(s.set))
+	clear(s.set)
+	s.modified()
+	return out
+}
+
 // Add an element to the set. Returns true if the element was added, false if it was already present.
 func (s *Map[M]) Add(m M) bool {
 	// single map operation; the length only changes when the element wasn't already present
 	before := len(s.set)
 	s.set[m] = struct{}{}
-	return len(s.set) > before
+	if len(s.set) == before {
+		return false
+	}
+	s.hw = max(s.hw, before+1)
+	s.modified()
+	return true
+}
+
+// Cap returns an estimate of how many elements the set can hold before its backing map next grows, for deciding when
+// to Grow it ahead of a burst of additions. Go does not report a map's capacity, so this is a high-water mark: the most
+// elements the set has held, or been sized for by NewWith or Grow, since its map was made. It is approximate, as the
+// runtime rounds a map's size up and may grow it before it is full, but it never shrinks on removal, just as Go maps
+// keep their memory when elements are deleted; Clear and Drain keep the map, and so the estimate, too.
+func (s *Map[M]) Cap() int {
+	if s == nil {
+		return 0
+	}
+	return max(s.hw, len(s.set))
+}
+
+// Grow makes room for at least n more elements, so that adding them does not grow the backing map step by step. Go
+// maps cannot be grown in place, so growing a non-empty set copies it. It is a no-op if n <= 0 or Cap already leaves
+// room for them.
+func (s *Map[M]) Grow(n int) {
+	if n > 0 && len(s.set)+n > s.Cap() {
+		s.grow(n)
+	}
+}
+
+// Merge adds all of other's elements to the set in place and returns the number that were not already present. It is
+// the in-place form of Union, avoiding the copy Union makes.
+func (s *Map[M]) Merge(other Set[M]) int {
+	return AppendSeq[M](s, orEmpty(other).Iterator)
+}
+
+// RemoveAll removes all of other's elements from the set in place and returns the number removed. It is the in-place
+// form of Difference, avoiding the copy Difference makes.
+func (s *Map[M]) RemoveAll(other Set[M]) int {
+	if Set[M](s) == other {
+		return s.Clear()
+	}
+	return RemoveSeq[M](s, orEmpty(other).Iterator)
+}
+
+// Keep removes every element that is not in other from the set in place and returns the number removed. It is the
+// in-place form of Intersection, avoiding the copy Intersection makes.
+func (s *Map[M]) Keep(other Set[M]) int {
+	if Set[M](s) == other {
+		return 0
+	}
+	return keepOnly[M](s, other)
 }
 
 // Remove an element from the set. Returns true if the element was removed, false if it was not present.
@@ -74,7 +236,11 @@ func (s *Map[M]) Remove(m M) bool {
 	// single map operation; the length only changes when the element was present
 	before := len(s.set)
 	delete(s.set, m)
-	return len(s.set) < before
+	if len(s.set) == before {
+		return false
+	}
+	s.modified()
+	return true
 }
 
 // Cardinality returns the number of elements in the set.
@@ -85,22 +251,47 @@ func (s *Map[M]) Cardinality() int {
 	return len(s.set)
 }
 
-// Iterator yields all elements in the set.
+// Iterator yields all elements in the set. The set must not be modified during iteration; built with the setsdebug
+// tag, Iterator panics if it is (see iterdebug.go).
 func (s *Map[M]) Iterator(yield func(M) bool) {
+	if s == nil {
+		return
+	}
+	mods := s.mods
 	for k := range s.set {
 		if !yield(k) {
 			return
 		}
+		if iterationChecks {
+			checkIteration(mods, s.mods)
+		}
 	}
 }
 
+// grow preallocates room for n more elements. Go maps cannot be grown in place, so a non-empty set is copied into a
+// map sized for both its current and its future elements.
+func (s *Map[M]) grow(n int) {
+	if len(s.set) == 0 {
+		s.set = make(map[M]struct{}, n)
+		s.hw = n
+		return
+	}
+	g := make(map[M]struct{}, len(s.set)+n)
+	maps.Copy(g, s.set)
+	s.set = g
+	s.hw = len(g) + n
+}
+
 // Clones the set. Returns a new set of the same underlying type.
 func (s *Map[M]) Clone() Set[M] {
+	if s == nil {
+		return New[M]()
+	}
 	c := maps.Clone(s.set)
 	if c == nil {
 		c = make(map[M]struct{})
 	}
-	return &Map[M]{set: c}
+	return &Map[M]{set: c, hw: len(c)}
 }
 
 // NewEmpty set of the same underlying type.
@@ -112,6 +303,7 @@ func (s *Map[M]) NewEmpty() Set[M] {
 func (s *Map[M]) Pop() (M, bool) {
 	for k := range s.set {
 		delete(s.set, k)
+		s.modified()
 		return k, true
 	}
 	var m M
@@ -143,7 +335,8 @@ func (s *Map[M]) MarshalJSON() ([]byte, error) {
 }
 
 // UnmarshalJSON unmarshals the set from JSON. It expects a JSON array of the elements in the set. If the set is empty,
-// it returns an empty set. If the JSON is invalid, it returns an error.
+// it returns an empty set. A JSON null also leaves the set empty, matching Scan(nil). If the JSON is invalid, it
+// returns an error.
 func (s *Map[M]) UnmarshalJSON(d []byte) error {
 	var um []M
 	if err := json.Unmarshal(d, &um); err != nil {
@@ -0,0 +1,108 @@
+package sets
+
+// MultiMap maps each key to a set of values, e.g. an index from a tag to the IDs of the items carrying it. Each key's
+// values are held in a set made by the constructor passed to NewMultiMap, so callers choose the value set type, e.g.
+// NewOrdered to keep values in insertion order. Keys without values are not kept: removing a key's last value removes
+// the key. It is not safe for concurrent use.
+//
+// MultiMap's zero value is not usable; create one with NewMultiMap.
+type MultiMap[K comparable, V comparable] struct {
+	m      map[K]Set[V]
+	newSet func() Set[V]
+}
+
+// NewMultiMap returns an empty *MultiMap[K, V] that holds each key's values in a set made by newSet. If newSet is nil,
+// values are held in a *Map[V].
+func NewMultiMap[K comparable, V comparable](newSet func() Set[V]) *MultiMap[K, V] {
+	if newSet == nil {
+		newSet = func() Set[V] { return New[V]() }
+	}
+	return &MultiMap[K, V]{m: make(map[K]Set[V]), newSet: newSet}
+}
+
+// Add the value to the key's set of values, creating the set if the key has none. Returns true if the value was added,
+// false if the key already had it.
+func (mm *MultiMap[K, V]) Add(k K, v V) bool {
+	s, ok := mm.m[k]
+	if !ok {
+		s = mm.newSet()
+		mm.m[k] = s
+	}
+	return s.Add(v)
+}
+
+// Remove the value from the key's set of values, removing the key if it has no values left. Returns true if the value
+// was removed, false if the key did not have it.
+func (mm *MultiMap[K, V]) Remove(k K, v V) bool {
+	s, ok := mm.m[k]
+	if !ok || !s.Remove(v) {
+		return false
+	}
+	if s.Cardinality() == 0 {
+		delete(mm.m, k)
+	}
+	return true
+}
+
+// RemoveKey removes the key and all of its values. Returns the number of values removed.
+func (mm *MultiMap[K, V]) RemoveKey(k K) int {
+	s, ok := mm.m[k]
+	if !ok {
+		return 0
+	}
+	delete(mm.m, k)
+	return s.Cardinality()
+}
+
+// Contains returns true if the key has the value.
+func (mm *MultiMap[K, V]) Contains(k K, v V) bool {
+	if mm == nil {
+		return false
+	}
+	s, ok := mm.m[k]
+	return ok && s.Contains(v)
+}
+
+// Get returns the key's set of values, or nil if the key has none; the package-level functions treat a nil set as
+// empty. The set is the one held by the MultiMap, not a copy, so it must not be modified directly: a key whose set is
+// emptied that way is not removed. Clone it to get an independent set.
+func (mm *MultiMap[K, V]) Get(k K) Set[V] {
+	if mm == nil {
+		return nil
+	}
+	return mm.m[k]
+}
+
+// Keys returns a new *Map[K] of the keys that have at least one value.
+func (mm *MultiMap[K, V]) Keys() Set[K] {
+	keys := New[K]()
+	if mm == nil {
+		return keys
+	}
+	keys.grow(len(mm.m))
+	for k := range mm.m {
+		keys.Add(k)
+	}
+	return keys
+}
+
+// Len returns the number of keys.
+func (mm *MultiMap[K, V]) Len() int {
+	if mm == nil {
+		return 0
+	}
+	return len(mm.m)
+}
+
+// TotalValues returns the number of values across all keys, the sum of the cardinalities of the keys' sets. A value
+// held by several keys is counted once for each.
+func (mm *MultiMap[K, V]) TotalValues() int {
+	if mm == nil {
+		return 0
+	}
+	var n int
+	for _, s := range mm.m {
+		n += s.Cardinality()
+	}
+	return n
+}
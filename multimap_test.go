@@ -0,0 +1,86 @@
+package sets
+
+import (
+	"slices"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// TestMultiMap checks Add, Remove, RemoveKey, and the accessors against a map of slices model.
+func TestMultiMap(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(t *rapid.T) {
+		mm := NewMultiMap[string, int](nil)
+		model := make(map[string][]int)
+
+		steps := rapid.IntRange(1, 100).Draw(t, "Steps")
+		for range steps {
+			k := rapid.SampledFrom([]string{"a", "b", "c"}).Draw(t, "Key")
+			v := rapid.IntRange(0, 5).Draw(t, "Value")
+			switch rapid.IntRange(0, 3).Draw(t, "Op") {
+			case 0, 1:
+				want := !slices.Contains(model[k], v)
+				if want {
+					model[k] = append(model[k], v)
+				}
+				if got := mm.Add(k, v); got != want {
+					t.Fatalf("Add(%q, %d) = %v, want %v", k, v, got, want)
+				}
+			case 2:
+				i := slices.Index(model[k], v)
+				if i >= 0 {
+					model[k] = slices.Delete(model[k], i, i+1)
+					if len(model[k]) == 0 {
+						delete(model, k)
+					}
+				}
+				if got := mm.Remove(k, v); got != (i >= 0) {
+					t.Fatalf("Remove(%q, %d) = %v, want %v", k, v, got, i >= 0)
+				}
+			case 3:
+				want := len(model[k])
+				delete(model, k)
+				if got := mm.RemoveKey(k); got != want {
+					t.Fatalf("RemoveKey(%q) = %d, want %d", k, got, want)
+				}
+			}
+
+			var total int
+			for k, vs := range model {
+				total += len(vs)
+				if !Equal(mm.Get(k), Set[int](NewWith(vs...))) {
+					t.Fatalf("Get(%q) = %v, want %v", k, mm.Get(k), vs)
+				}
+			}
+			if mm.Len() != len(model) || mm.TotalValues() != total {
+				t.Fatalf("Len() = %d, TotalValues() = %d, want %d, %d", mm.Len(), mm.TotalValues(), len(model), total)
+			}
+			for _, k := range []string{"a", "b", "c"} {
+				if _, ok := model[k]; ok != mm.Keys().Contains(k) {
+					t.Fatalf("Keys() = %v, model %v", mm.Keys(), model)
+				}
+				if ok := mm.Contains(k, v); ok != slices.Contains(model[k], v) {
+					t.Fatalf("Contains(%q, %d) = %v", k, v, ok)
+				}
+			}
+		}
+	})
+}
+
+func TestMultiMap_InnerSetType(t *testing.T) {
+	t.Parallel()
+
+	mm := NewMultiMap[string, int](func() Set[int] { return NewOrdered[int]() })
+	for _, v := range []int{3, 1, 2, 1} {
+		mm.Add("k", v)
+	}
+	got, ok := mm.Get("k").(*Ordered[int])
+	if !ok || !slices.Equal(Elements[int](got), []int{3, 1, 2}) {
+		t.Fatalf("Get(\"k\") = %v (%T), want ordered [3 1 2]", mm.Get("k"), mm.Get("k"))
+	}
+	if mm.Get("missing") != nil {
+		t.Fatalf("Get of a missing key = %v, want nil", mm.Get("missing"))
+	}
+}
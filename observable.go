@@ -0,0 +1,222 @@
+package sets
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// Observable wraps a Set[M] and calls registered hooks after elements are added to or removed from it, turning any set
+// into an event source, e.g. for invalidating a cache entry when its key leaves the set. Hooks only fire for real
+// changes: adding an element that is already present or removing one that is not fires nothing. Every removal fires
+// the remove hooks, whether by Remove, Pop, Clear, or Drain.
+//
+// Mutations are delegated to the inner set, and the hooks then run on the goroutine that made the change, after the
+// inner set's method has returned. They therefore never run under a lock held by the inner set. Observable adds no
+// locking of its own: it is safe for concurrent use only if the inner set is (e.g. a Locked set), the hooks are
+// registered before the set is shared, and the hooks themselves are safe to call concurrently. Concurrent changes
+// may then fire their hooks in a different order than the changes were made.
+//
+// Observable's zero value is not usable; create one with NewObservable.
+type Observable[M comparable] struct {
+	set      Set[M]
+	onAdd    []func(M)
+	onRemove []func(M)
+}
+
+var _ Set[int] = new(Observable[int])
+var _ driver.Valuer = new(Observable[int])
+
+// NewObservable returns an *Observable[M] wrapping set, with no hooks registered. The Observable takes over the set:
+// changes made directly to set do not fire hooks.
+func NewObservable[M comparable](set Set[M]) *Observable[M] {
+	return &Observable[M]{set: set}
+}
+
+// OnAdd registers fn to be called with each element added to the set. Hooks are called in the order they were
+// registered. OnAdd is not safe to call concurrently with other methods.
+func (s *Observable[M]) OnAdd(fn func(M)) {
+	s.onAdd = append(s.onAdd, fn)
+}
+
+// OnRemove registers fn to be called with each element removed from the set. Hooks are called in the order they were
+// registered. OnRemove is not safe to call concurrently with other methods.
+func (s *Observable[M]) OnRemove(fn func(M)) {
+	s.onRemove = append(s.onRemove, fn)
+}
+
+func (s *Observable[M]) added(m M) {
+	for _, fn := range s.onAdd {
+		fn(m)
+	}
+}
+
+func (s *Observable[M]) removed(m M) {
+	for _, fn := range s.onRemove {
+		fn(m)
+	}
+}
+
+// Contains returns true if the set contains the element.
+func (s *Observable[M]) Contains(m M) bool {
+	if s == nil {
+		return false
+	}
+	return s.set.Contains(m)
+}
+
+// Clear the set and returns the number of elements removed. The remove hooks are called for each removed element.
+func (s *Observable[M]) Clear() int {
+	if len(s.onRemove) == 0 {
+		return s.set.Clear()
+	}
+	return len(s.Drain())
+}
+
+// Drain removes all elements from the set and returns them. Returns nil if the set is empty. The remove hooks are
+// called for each removed element.
+func (s *Observable[M]) Drain() []M {
+	out := Drain(s.set)
+	for _, m := range out {
+		s.removed(m)
+	}
+	return out
+}
+
+// Add an element to the set and call the add hooks with it. Returns true if the element was added, false if it was
+// already present, in which case no hooks are called.
+func (s *Observable[M]) Add(m M) bool {
+	if !s.set.Add(m) {
+		return false
+	}
+	s.added(m)
+	return true
+}
+
+// Merge adds all of other's elements to the set, calling the add hooks with each one that was not already present, and
+// returns how many there were.
+func (s *Observable[M]) Merge(other Set[M]) int {
+	return AppendSeq[M](s, orEmpty(other).Iterator)
+}
+
+// RemoveAll removes all of other's elements from the set, calling the remove hooks with each one that was present, and
+// returns how many there were.
+func (s *Observable[M]) RemoveAll(other Set[M]) int {
+	if Set[M](s) == other {
+		return s.Clear()
+	}
+	return RemoveSeq[M](s, orEmpty(other).Iterator)
+}
+
+// Keep removes every element that is not in other from the set, calling the remove hooks with each one, and returns how
+// many there were.
+func (s *Observable[M]) Keep(other Set[M]) int {
+	if Set[M](s) == other {
+		return 0
+	}
+	return keepOnly[M](s, other)
+}
+
+// Remove an element from the set and call the remove hooks with it. Returns true if the element was removed, false if
+// it was not present, in which case no hooks are called.
+func (s *Observable[M]) Remove(m M) bool {
+	if !s.set.Remove(m) {
+		return false
+	}
+	s.removed(m)
+	return true
+}
+
+// Pop removes and returns an element from the set, calling the remove hooks with it. If the set is empty, it returns
+// the zero value of M and false.
+func (s *Observable[M]) Pop() (M, bool) {
+	m, ok := s.set.Pop()
+	if ok {
+		s.removed(m)
+	}
+	return m, ok
+}
+
+// Cardinality returns the number of elements in the set.
+func (s *Observable[M]) Cardinality() int {
+	if s == nil {
+		return 0
+	}
+	return s.set.Cardinality()
+}
+
+// Iterator yields all elements in the set, as the inner set's Iterator does.
+func (s *Observable[M]) Iterator(yield func(M) bool) {
+	if s == nil {
+		return
+	}
+	s.set.Iterator(yield)
+}
+
+// Clone returns a new *Observable wrapping a clone of the inner set. Hooks are not copied, so changes to the clone
+// fire nothing until hooks are registered on it.
+func (s *Observable[M]) Clone() Set[M] {
+	if s == nil {
+		return NewObservable[M](New[M]())
+	}
+	return NewObservable(s.set.Clone())
+}
+
+// NewEmpty returns a new empty *Observable wrapping a set of the same underlying type as the inner set, with no hooks
+// registered.
+func (s *Observable[M]) NewEmpty() Set[M] {
+	if s == nil {
+		return NewObservable[M](New[M]())
+	}
+	return NewObservable(s.set.NewEmpty())
+}
+
+// String returns a string representation of the set. It returns a string of the form Observable<inner set>, e.g.
+// ObservableSet[T](<elements>) for an inner Map.
+func (s *Observable[M]) String() string {
+	return "Observable" + s.set.String()
+}
+
+// stringPrefix returns the prefix of String: Observable followed by the inner set's prefix.
+//
+//lint:ignore U1000 reached via the stringPrefixer type assertion in stringPrefix
+func (s *Observable[M]) stringPrefix() string {
+	return "Observable" + stringPrefix(s.set)
+}
+
+// Value implements the driver.Valuer interface. It returns the JSON representation of the set.
+func (s *Observable[M]) Value() (driver.Value, error) {
+	return s.MarshalJSON()
+}
+
+// MarshalJSON implements json.Marshaler. It will marshal the set into a JSON array of the elements in the set. If the
+// set is empty an empty JSON array is returned. The inner set's own MarshalJSON is used when it has one.
+func (s *Observable[M]) MarshalJSON() ([]byte, error) {
+	d, err := marshalInner(s.set)
+	if err != nil {
+		return d, fmt.Errorf("marshaling observable set: %w", err)
+	}
+	return d, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It expects a JSON array of the elements in the set. The set's current
+// elements are removed and the array's elements added, one at a time, so the hooks see every change. If the JSON is
+// invalid, it returns an error and the set is left unchanged.
+func (s *Observable[M]) UnmarshalJSON(d []byte) error {
+	var um []M
+	if err := json.Unmarshal(d, &um); err != nil {
+		return fmt.Errorf("unmarshaling observable set: %w", err)
+	}
+	s.Clear()
+	for _, m := range um {
+		s.Add(m)
+	}
+	return nil
+}
+
+// Scan implements the sql.Scanner interface. It scans the value from the database into the set. It expects a JSON array
+// of the elements in the set. If the JSON is invalid an error is returned. If the value is nil an empty set is
+// returned.
+func (s *Observable[M]) Scan(src any) error {
+	return scanValue[M](src, s.Clear, s.UnmarshalJSON)
+}
@@ -0,0 +1,118 @@
+package sets
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+func TestObservable(t *testing.T) {
+	t.Parallel()
+
+	setStateMachine := &SetStateMachine{
+		set:    NewObservable[int](New[int]()),
+		stateI: make(map[int]int),
+	}
+	rapid.Check(t, func(t *rapid.T) {
+		t.Repeat(rapid.StateMachineActions(setStateMachine))
+	})
+}
+
+// TestObservable_Hooks checks that the hooks see exactly the changes made to the set, by replaying them onto a model.
+func TestObservable_Hooks(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(t *rapid.T) {
+		s := NewObservable[int](NewOrdered[int]())
+		model := New[int]()
+		var calls int
+		s.OnAdd(func(m int) {
+			calls++
+			if !model.Add(m) {
+				t.Fatalf("add hook called for %d, which was already present", m)
+			}
+		})
+		s.OnRemove(func(m int) {
+			calls++
+			if !model.Remove(m) {
+				t.Fatalf("remove hook called for %d, which was not present", m)
+			}
+		})
+
+		steps := rapid.IntRange(1, 100).Draw(t, "Steps")
+		for range steps {
+			v := rapid.IntRange(0, 10).Draw(t, "Value")
+			before := calls
+			var changed bool
+			switch rapid.IntRange(0, 5).Draw(t, "Op") {
+			case 0, 1:
+				changed = s.Add(v)
+			case 2:
+				changed = s.Remove(v)
+			case 3:
+				_, changed = s.Pop()
+			case 4:
+				changed = s.Clear() > 0
+			case 5:
+				changed = len(Drain[int](s)) > 0
+			}
+			if !changed && calls != before {
+				t.Fatalf("hooks called %d times for an operation that changed nothing", calls-before)
+			}
+			if !Equal[int](s, model) {
+				t.Fatalf("set %v, hook model %v", s, model)
+			}
+		}
+	})
+}
+
+func TestObservable_MultipleHooks(t *testing.T) {
+	s := NewObservable[string](New[string]())
+	var got []string
+	s.OnAdd(func(m string) { got = append(got, "first "+m) })
+	s.OnAdd(func(m string) { got = append(got, "second "+m) })
+
+	s.Add("a")
+	s.Add("a")
+	if want := []string{"first a", "second a"}; !slices.Equal(got, want) {
+		t.Fatalf("hooks called %q, want %q", got, want)
+	}
+
+	c := s.Clone()
+	c.Add("b")
+	if len(got) != 2 {
+		t.Fatalf("adding to a clone called the original's hooks: %q", got)
+	}
+	if _, ok := c.(*Observable[string]); !ok {
+		t.Fatalf("Clone() = %T, want *Observable[string]", c)
+	}
+}
+
+func TestObservable_JSON(t *testing.T) {
+	s := NewObservable[int](NewOrderedWith(1, 2))
+	var added, removed []int
+	s.OnAdd(func(m int) { added = append(added, m) })
+	s.OnRemove(func(m int) { removed = append(removed, m) })
+
+	if err := json.Unmarshal([]byte("[3,4,3]"), s); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(removed, []int{1, 2}) || !slices.Equal(added, []int{3, 4}) {
+		t.Fatalf("unmarshal removed %v and added %v, want [1 2] and [3 4]", removed, added)
+	}
+	d, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(d) != "[3,4]" {
+		t.Fatalf("Marshal = %s, want [3,4]", d)
+	}
+	if err := json.Unmarshal([]byte("{"), s); err == nil {
+		t.Fatal("expected an error for invalid JSON")
+	}
+	if s.Cardinality() != 2 {
+		t.Fatalf("invalid JSON changed the set to %v", s)
+	}
+}
@@ -6,7 +6,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"iter"
+	"maps"
+	"math/bits"
+	"reflect"
 	"slices"
+	"strconv"
 )
 
 // Ordered maintains the order that elements were added in. It uses a gap buffer with a Fenwick tree
@@ -15,29 +19,56 @@ import (
 //
 // Complexity:
 //   - Add: O(1) amortized
+//   - AddSorted: O(N) (O(1) amortized when the element sorts last)
 //   - Remove: O(log N) amortized
 //   - Contains: O(1)
-//   - At: O(log N)
+//   - At: O(log N), with negative indexes counting back from the end
+//   - AtMany: O(K log N) for K indexes
+//   - Head, Tail: O(log N + K) for K elements
 //   - Index: O(log N)
+//   - IndexFunc: O(N)
 //   - Iterator: O(N)
+//   - MoveToFront: O(N)
+//   - MoveToBack: O(log N) amortized
+//   - Rotate: O(N)
+//   - Swap: O(log N)
+//   - Sort, SortStable: O(N log N)
+//   - Transform: O(N)
+//   - Max, Min: O(log N) when the set is known to be sorted, otherwise the package-level Max/Min iterate in O(N)
+//   - IsSorted: O(1) when the set is known to be sorted, otherwise O(N)
+//
+// The set tracks whether it is known to be in ascending order: Sort and SortStable set the flag, and Add (or
+// MoveToBack) clears it when the element it appends is not larger than the current last element. AddSorted and
+// removals keep it. The check is conservative, e.g. MoveToFront, Swap, and SortBy always clear it, so a set may be
+// sorted without being known to be.
 type Ordered[M cmp.Ordered] struct {
 	idx   map[M]int // element -> physical slot index
 	slots []M       // physical slots (may contain gaps from removals)
 	alive []bool    // slot occupancy bitmap
 	bit   []int     // Fenwick tree (1-indexed) for prefix sums of alive slots
 	count int       // number of alive elements
+
+	sorted bool   // known to be in ascending order; see the type's doc for how it is maintained
+	mods   uint64 // modification count, maintained only with the setsdebug build tag; see iterdebug.go
 }
 
 var _ OrderedSet[int] = new(Ordered[int])
 var _ driver.Valuer = new(Ordered[int])
+var _ capacitySet = new(Ordered[int])
+var _ seqRemover[int] = new(Ordered[int])
+var _ Maxer[int] = new(Ordered[int])
+var _ Minner[int] = new(Ordered[int])
+var _ sortTracker = new(Ordered[int])
+var _ stableSorter[int] = new(Ordered[int])
 
 // NewOrdered returns an empty *Ordered[M].
 func NewOrdered[M cmp.Ordered]() *Ordered[M] {
 	return &Ordered[M]{
-		idx:   make(map[M]int),
-		slots: make([]M, 0),
-		alive: make([]bool, 0),
-		bit:   make([]int, 1), // bit[0] is unused sentinel
+		idx:    make(map[M]int),
+		slots:  make([]M, 0),
+		alive:  make([]bool, 0),
+		bit:    make([]int, 1), // bit[0] is unused sentinel
+		sorted: true,
 	}
 }
 
@@ -55,6 +86,12 @@ func NewOrderedWith[M cmp.Ordered](m ...M) *Ordered[M] {
 	return NewOrderedFrom(slices.Values(m))
 }
 
+// NewOrderedFromString returns a new *Ordered[rune] containing the distinct runes of str in the order they first
+// appear. Invalid UTF-8 bytes are added as utf8.RuneError, as when ranging over a string.
+func NewOrderedFromString(str string) *Ordered[rune] {
+	return NewOrderedFrom(runes(str))
+}
+
 // --- Fenwick tree (binary indexed tree) operations ---
 
 func (s *Ordered[M]) bitUpdate(i, delta int) {
@@ -141,6 +178,67 @@ func (s *Ordered[M]) maybeCompact() {
 	}
 }
 
+// Compact removes the gaps that Remove leaves behind in the backing storage, releasing their memory. Removals compact
+// automatically once gaps outnumber elements, so calling Compact is only needed to reclaim memory sooner, e.g. after a
+// large batch of removals from a set that will not grow again.
+func (s *Ordered[M]) Compact() {
+	s.compact()
+	s.modified()
+}
+
+// removeSeq removes every element of seq as a single batch and returns the number removed. Per-element Remove pays an
+// O(log N) Fenwick tree update for each removal; a large batch instead marks its elements dead and then rebuilds the
+// tree once in O(N), so removing a sizable fraction of the set costs O(N) rather than O(K log N). The sequence is
+// collected before the set is modified, so it may safely read from the set.
+//
+//lint:ignore U1000 reached via the seqRemover type assertion in the package-level RemoveSeq
+func (s *Ordered[M]) removeSeq(seq iter.Seq[M]) int {
+	// past this many removals one O(N) rebuild beats per-removal O(log N) updates, so positions stop being recorded
+	limit := len(s.slots) / bits.Len(uint(len(s.bit)))
+	var n int
+	var dead []int
+	for _, m := range slices.Collect(seq) {
+		p, ok := s.idx[m]
+		if !ok {
+			continue
+		}
+		delete(s.idx, m)
+		s.alive[p] = false
+		s.count--
+		if n++; n <= limit {
+			dead = append(dead, p)
+		}
+	}
+	if n > 0 {
+		s.modified()
+	}
+	if n > limit {
+		s.rebuildBIT()
+	} else {
+		for _, p := range dead {
+			s.bitUpdate(p, -1)
+		}
+	}
+	s.maybeCompact()
+	return n
+}
+
+// grow preallocates room for n more elements in the index map and the slot arrays; see Map.grow for why a non-empty
+// index map is copied.
+//
+//lint:ignore U1000 reached via the capacitySet type assertion in the package-level grow
+func (s *Ordered[M]) grow(n int) {
+	if len(s.idx) == 0 {
+		s.idx = make(map[M]int, n)
+	} else {
+		idx := make(map[M]int, len(s.idx)+n)
+		maps.Copy(idx, s.idx)
+		s.idx = idx
+	}
+	s.slots = slices.Grow(s.slots, n)
+	s.alive = slices.Grow(s.alive, n)
+}
+
 // elements returns a slice of all alive elements in insertion order.
 func (s *Ordered[M]) elements() []M {
 	out := make([]M, 0, s.count)
@@ -156,11 +254,15 @@ func (s *Ordered[M]) elements() []M {
 
 // Contains returns true if the set contains the element.
 func (s *Ordered[M]) Contains(m M) bool {
+	if s == nil {
+		return false
+	}
 	_, ok := s.idx[m]
 	return ok
 }
 
-// Clear the set and returns the number of elements removed.
+// Clear the set and returns the number of elements removed. Use Drain instead to also get the removed elements, in
+// their prior order.
 func (s *Ordered[M]) Clear() int {
 	n := s.count
 	if s.idx == nil {
@@ -181,20 +283,45 @@ func (s *Ordered[M]) Clear() int {
 	}
 	s.bit = make([]int, 1)
 	s.count = 0
+	s.sorted = true
+	s.modified()
 	return n
 }
 
+// modified records a modification of the set for the setsdebug iteration checks.
+func (s *Ordered[M]) modified() {
+	if iterationChecks {
+		s.mods++
+	}
+}
+
+// Drain removes all elements from the set and returns them in order. Returns nil if the set is empty.
+func (s *Ordered[M]) Drain() []M {
+	if s.count == 0 {
+		return nil
+	}
+	out := s.elements()
+	s.Clear()
+	return out
+}
+
 // Add an element to the set. Returns true if the element was added, false if it was already present. Elements are added
 // to the end of the ordered set.
 func (s *Ordered[M]) Add(m M) bool {
 	if s.Contains(m) {
 		return false
 	}
+	if s.sorted {
+		if last, ok := s.last(); ok && !cmp.Less(last, m) {
+			s.sorted = false
+		}
+	}
 	p := len(s.slots)
 	s.slots = append(s.slots, m)
 	s.alive = append(s.alive, true)
 	s.idx[m] = p
 	s.count++
+	s.modified()
 	if p+2 > len(s.bit) {
 		s.rebuildBIT()
 	} else {
@@ -203,6 +330,70 @@ func (s *Ordered[M]) Add(m M) bool {
 	return true
 }
 
+// last returns the last alive element, scanning back over the dead slots that removals leave at the end. Add appends a
+// live slot after any dead ones it scans, so each is scanned at most once and the scan is O(1) amortized.
+func (s *Ordered[M]) last() (M, bool) {
+	for i := len(s.slots) - 1; i >= 0; i-- {
+		if s.alive[i] {
+			return s.slots[i], true
+		}
+	}
+	var zero M
+	return zero, false
+}
+
+// AddSorted adds an element at its sorted position, found by binary search, instead of at the end, so a set that is
+// sorted (see IsSorted) stays sorted without calling Sort again. Returns true if the element was added, false if it was
+// already present. Inserting before the last element shifts the elements after it, so AddSorted is O(N), or O(1)
+// amortized when the element sorts last. The invariant is the caller's: mixing Add, which appends, with AddSorted
+// leaves the set unsorted, after which AddSorted still inserts at a binary-search position but the set does not become
+// sorted again until Sort is called.
+func (s *Ordered[M]) AddSorted(m M) bool {
+	if s.Contains(m) {
+		return false
+	}
+	s.compact() // every slot alive, so slots is the logical order
+	if s.count == 0 || cmp.Less(s.slots[s.count-1], m) {
+		return s.Add(m)
+	}
+	i, _ := slices.BinarySearch(s.slots, m)
+	s.slots = slices.Insert(s.slots, i, m)
+	s.alive = slices.Insert(s.alive, i, true)
+	s.count++
+	s.modified()
+	for j := i; j < len(s.slots); j++ {
+		s.idx[s.slots[j]] = j
+	}
+	s.rebuildBIT()
+	return true
+}
+
+// Merge appends other's elements that are not already present to the end of the set, in other's iteration order, and
+// returns the number appended. It is the in-place form of Union, avoiding the copy Union makes.
+func (s *Ordered[M]) Merge(other Set[M]) int {
+	return AppendSeq[M](s, orEmpty(other).Iterator)
+}
+
+// RemoveAll removes all of other's elements from the set in place and returns the number removed. It is the in-place
+// form of Difference, avoiding the copy Difference makes. The removals are batched as in RemoveSeq, so removing many
+// elements costs O(N) rather than O(K log N).
+func (s *Ordered[M]) RemoveAll(other Set[M]) int {
+	if Set[M](s) == other {
+		return s.Clear()
+	}
+	return RemoveSeq[M](s, orEmpty(other).Iterator)
+}
+
+// Keep removes every element that is not in other from the set in place and returns the number removed; the remaining
+// elements keep their order. It is the in-place form of Intersection, avoiding the copy Intersection makes. The
+// removals are batched as in RemoveAll.
+func (s *Ordered[M]) Keep(other Set[M]) int {
+	if Set[M](s) == other {
+		return 0
+	}
+	return keepOnly[M](s, other)
+}
+
 // Remove an element from the set. Returns true if the element was removed, false if it was not present.
 func (s *Ordered[M]) Remove(m M) bool {
 	p, ok := s.idx[m]
@@ -213,6 +404,7 @@ func (s *Ordered[M]) Remove(m M) bool {
 	s.bitUpdate(p, -1)
 	delete(s.idx, m)
 	s.count--
+	s.modified()
 	s.maybeCompact()
 	return true
 }
@@ -225,19 +417,30 @@ func (s *Ordered[M]) Cardinality() int {
 	return s.count
 }
 
-// Iterator yields all elements in the set in order.
+// Iterator yields all elements in the set in order. The set must not be modified during iteration; built with the
+// setsdebug tag, Iterator, Ordered, and Backwards panic if it is (see iterdebug.go).
 func (s *Ordered[M]) Iterator(yield func(M) bool) {
+	if s == nil {
+		return
+	}
+	mods := s.mods
 	for i, v := range s.slots {
 		if s.alive[i] {
 			if !yield(v) {
 				return
 			}
+			if iterationChecks {
+				checkIteration(mods, s.mods)
+			}
 		}
 	}
 }
 
 // Clone returns a copy of the set. The underlying type is the same as the original set.
 func (s *Ordered[M]) Clone() Set[M] {
+	if s == nil {
+		return NewOrdered[M]()
+	}
 	// bulk copy (compacted) instead of re-adding element by element, which would
 	// pay a map insert and Fenwick tree update per element
 	c := &Ordered[M]{
@@ -245,6 +448,8 @@ func (s *Ordered[M]) Clone() Set[M] {
 		slots: s.elements(),
 		alive: make([]bool, s.count),
 		count: s.count,
+
+		sorted: s.sorted,
 	}
 	for i, v := range c.slots {
 		c.alive[i] = true
@@ -256,12 +461,16 @@ func (s *Ordered[M]) Clone() Set[M] {
 
 // Ordered iteration yields the index and value of each element in the set in order.
 func (s *Ordered[M]) Ordered(yield func(int, M) bool) {
+	mods := s.mods
 	var j int
 	for i, v := range s.slots {
 		if s.alive[i] {
 			if !yield(j, v) {
 				return
 			}
+			if iterationChecks {
+				checkIteration(mods, s.mods)
+			}
 			j++
 		}
 	}
@@ -269,12 +478,16 @@ func (s *Ordered[M]) Ordered(yield func(int, M) bool) {
 
 // Backwards iteration yields the index and value of each element in the set in reverse order.
 func (s *Ordered[M]) Backwards(yield func(int, M) bool) {
+	mods := s.mods
 	j := s.count - 1
 	for i := len(s.slots) - 1; i >= 0; i-- {
 		if s.alive[i] {
 			if !yield(j, s.slots[i]) {
 				return
 			}
+			if iterationChecks {
+				checkIteration(mods, s.mods)
+			}
 			j--
 		}
 	}
@@ -307,12 +520,206 @@ func (s *Ordered[M]) Sort() {
 	for i, v := range s.slots {
 		s.idx[v] = i
 	}
+	s.sorted = true
+	s.modified()
 	// BIT is all-ones after compact; sort doesn't change alive status.
 }
 
-// At returns the element at the index. If the index is out of bounds, the second return value is false.
+// SortStable sorts the set in ascending order like Sort, but stably: elements that compare equal keep their prior
+// relative order, so the earlier-inserted (or earlier-moved) one stays first. Distinct elements of a cmp.Ordered type
+// only compare equal when they are floating point NaNs, which cmp.Compare treats as equal to each other, so SortStable
+// differs from Sort only in where it leaves multiple NaNs. To sort by a custom key with the same tie-breaking, use
+// SortBy.
+func (s *Ordered[M]) SortStable() {
+	s.sortStableFunc(cmp.Compare[M])
+	s.sorted = true
+}
+
+// sortStableFunc stably sorts the elements by cmp in place, compacting first so the Fenwick tree stays all ones.
+//
+//lint:ignore U1000 reached via the stableSorter type assertion in sortStable
+func (s *Ordered[M]) sortStableFunc(cmp func(M, M) int) {
+	s.compact()
+	slices.SortStableFunc(s.slots, cmp)
+	for i, v := range s.slots {
+		s.idx[v] = i
+	}
+	s.sorted = false
+	s.modified()
+}
+
+// MoveToFront moves an element already in the set to the front of the order, e.g. to keep a most-recently-used
+// element first. Returns false, leaving the set unchanged, if the element is not present. The elements before it
+// shift back one position, so MoveToFront is O(N).
+func (s *Ordered[M]) MoveToFront(m M) bool {
+	p, ok := s.idx[m]
+	if !ok {
+		return false
+	}
+	copy(s.slots[1:p+1], s.slots[:p])
+	copy(s.alive[1:p+1], s.alive[:p])
+	s.slots[0], s.alive[0] = m, true
+	s.sorted = false
+	s.modified()
+	for i := 0; i <= p; i++ {
+		if s.alive[i] {
+			s.idx[s.slots[i]] = i
+		}
+	}
+	s.rebuildBIT()
+	return true
+}
+
+// MoveToBack moves an element already in the set to the back of the order, e.g. to keep a least-recently-used
+// element first for eviction. Returns false, leaving the set unchanged, if the element is not present. It is a
+// Remove followed by an Add, so MoveToBack is O(log N) amortized.
+func (s *Ordered[M]) MoveToBack(m M) bool {
+	p, ok := s.idx[m]
+	if !ok {
+		return false
+	}
+	if s.bitQuery(p) == s.count { // already last
+		return true
+	}
+	s.Remove(m)
+	s.Add(m)
+	return true
+}
+
+// Swap exchanges the elements at indexes i and j, e.g. to implement a custom sort or a user-driven reordering; with At
+// and Index, it lets callers apply any permutation. As with At, a negative index counts back from the end. Returns
+// false, leaving the set unchanged, if either index is out of bounds. Only the two elements move, so Swap is O(log N)
+// to locate them.
+func (s *Ordered[M]) Swap(i, j int) bool {
+	if i < 0 {
+		i += s.count
+	}
+	if j < 0 {
+		j += s.count
+	}
+	if i < 0 || i >= s.count || j < 0 || j >= s.count {
+		return false
+	}
+	if i == j {
+		return true
+	}
+	p, q := s.bitFindKth(i), s.bitFindKth(j)
+	s.slots[p], s.slots[q] = s.slots[q], s.slots[p]
+	s.idx[s.slots[p]], s.idx[s.slots[q]] = p, q
+	s.sorted = false
+	s.modified()
+	return true
+}
+
+// knownSorted reports whether the set is known to be in ascending order; see the type's doc.
+//
+//lint:ignore U1000 reached via the sortTracker type assertion in IsSorted
+func (s *Ordered[M]) knownSorted() bool {
+	return s == nil || s.sorted
+}
+
+// Max implements Maxer: when the set is known to be sorted (see the type's doc), it returns the last element in
+// O(log N). The second return value is false if the set is empty or not known to be sorted, in which case the
+// package-level Max falls back to iterating. Prefer the package-level Max function, which uses this automatically.
+func (s *Ordered[M]) Max() (M, bool) {
+	if s == nil || !s.sorted {
+		var zero M
+		return zero, false
+	}
+	return s.At(s.count - 1)
+}
+
+// Min implements Minner: when the set is known to be sorted (see the type's doc), it returns the first element in
+// O(log N). The second return value is false if the set is empty or not known to be sorted, in which case the
+// package-level Min falls back to iterating. Prefer the package-level Min function, which uses this automatically.
+func (s *Ordered[M]) Min() (M, bool) {
+	if s == nil || !s.sorted {
+		var zero M
+		return zero, false
+	}
+	return s.At(0)
+}
+
+// Rotate cyclically shifts the elements n positions to the left, so the element at index n becomes the first and the
+// first n elements move to the back, e.g. to hand out a set of workers round-robin, rotating by one each cycle. A
+// negative n shifts to the right. n may exceed the cardinality; it is taken modulo the cardinality. Membership is
+// unchanged. Rotate is O(N).
+func (s *Ordered[M]) Rotate(n int) {
+	if s.count < 2 {
+		return
+	}
+	if n %= s.count; n < 0 {
+		n += s.count
+	}
+	if n == 0 {
+		return
+	}
+	s.compact() // every slot alive, so slots is the logical order and the Fenwick tree is unaffected
+	slices.Reverse(s.slots[:n])
+	slices.Reverse(s.slots[n:])
+	slices.Reverse(s.slots)
+	for i, v := range s.slots {
+		s.idx[v] = i
+	}
+	s.sorted = false
+	s.modified()
+}
+
+// Transform replaces each element of s with f(element), in place, keeping the order, e.g. to normalize a set of
+// strings with strings.ToLower. When f maps several elements to the same value, only the first, in order, is kept, so
+// collisions reduce the cardinality. The set is rebuilt, so Transform is O(N).
+func Transform[M cmp.Ordered](s *Ordered[M], f func(M) M) {
+	if s.count == 0 {
+		return
+	}
+	slots := make([]M, 0, s.count)
+	idx := make(map[M]int, s.count)
+	sorted := true
+	for i, v := range s.slots {
+		if !s.alive[i] {
+			continue
+		}
+		v = f(v)
+		if _, ok := idx[v]; ok {
+			continue
+		}
+		if n := len(slots); n > 0 && !cmp.Less(slots[n-1], v) { // as in Add, so NaN is ordered as cmp orders it
+			sorted = false
+		}
+		idx[v] = len(slots)
+		slots = append(slots, v)
+	}
+	s.idx = idx
+	s.slots = slots
+	s.alive = make([]bool, len(slots))
+	for i := range s.alive {
+		s.alive[i] = true
+	}
+	s.count = len(slots)
+	s.sorted = sorted
+	s.rebuildBIT()
+	s.modified()
+}
+
+// IndexFunc returns the index of the first element, in order, for which the function returns true, or -1 if there is
+// none. Unlike Index, which looks an element up directly, it is a linear scan, so IndexFunc is O(N).
+func (s *Ordered[M]) IndexFunc(f func(M) bool) int {
+	for i, v := range s.Ordered {
+		if f(v) {
+			return i
+		}
+	}
+	return -1
+}
+
+// At returns the element at the index. A negative index counts back from the end, as in Python: At(-1) returns the last
+// element and At(-Cardinality()) the first. If the index is out of bounds (i >= Cardinality() or i < -Cardinality()),
+// the second return value is false.
 func (s *Ordered[M]) At(i int) (M, bool) {
 	var zero M
+	if i < 0 {
+		i += s.count
+	}
 	if i < 0 || i >= s.count {
 		return zero, false
 	}
@@ -320,6 +727,45 @@ func (s *Ordered[M]) At(i int) (M, bool) {
 	return s.slots[p], true
 }
 
+// AtMany returns the elements at the indexes, in the order requested, e.g. to fetch a page or a scattered selection of
+// elements in one call. Indexes are interpreted as by At, so negative ones count back from the end. An out-of-bounds
+// index yields the zero value of M, so out[i] is always the element at indices[i]; compare the indexes against
+// Cardinality first if the zero value could also be an element.
+func (s *Ordered[M]) AtMany(indices ...int) []M {
+	out := make([]M, len(indices))
+	for i, j := range indices {
+		out[i], _ = s.At(j)
+	}
+	return out
+}
+
+// Head returns a new *Ordered[M] holding the first n elements of the set, in order, e.g. the top n after a Sort. n is
+// clamped to [0, Cardinality()], so a negative n gives an empty set and an over-large one a copy. The set is unchanged.
+func (s *Ordered[M]) Head(n int) OrderedSet[M] {
+	return s.between(0, min(max(n, 0), s.count))
+}
+
+// Tail returns a new *Ordered[M] holding the last n elements of the set, in order. n is clamped as in Head. The set is
+// unchanged.
+func (s *Ordered[M]) Tail(n int) OrderedSet[M] {
+	return s.between(s.count-min(max(n, 0), s.count), s.count)
+}
+
+// between returns a new *Ordered[M] holding the elements at indexes lo through hi-1, starting the walk at lo's slot.
+func (s *Ordered[M]) between(lo, hi int) *Ordered[M] {
+	c := NewOrdered[M]()
+	if lo >= hi {
+		return c
+	}
+	c.grow(hi - lo)
+	for p := s.bitFindKth(lo); c.count < hi-lo; p++ {
+		if s.alive[p] {
+			c.Add(s.slots[p])
+		}
+	}
+	return c
+}
+
 // Index returns the index of the element in the set, or -1 if not present.
 func (s *Ordered[M]) Index(m M) int {
 	p, ok := s.idx[m]
@@ -356,7 +802,9 @@ func (s *Ordered[M]) MarshalJSON() ([]byte, error) {
 }
 
 // UnmarshalJSON implements json.Unmarshaler. It expects a JSON array of the elements in the set. If the set is empty,
-// it returns an empty set. If the JSON is invalid, it returns an error.
+// it returns an empty set, as it does for a JSON null, matching Scan(nil). Duplicate elements in the array are
+// dropped, keeping the position of each element's first occurrence, so the result is always a true set. If the JSON is
+// invalid, it returns an error.
 func (s *Ordered[M]) UnmarshalJSON(d []byte) error {
 	t := make([]M, 0)
 	if err := json.Unmarshal(d, &t); err != nil {
@@ -371,6 +819,85 @@ func (s *Ordered[M]) UnmarshalJSON(d []byte) error {
 	return nil
 }
 
+// MarshalJSONObject is an alternative to MarshalJSON that marshals the set into a JSON object mapping each element to
+// its index, in order, e.g. {"low":0,"medium":1,"high":2}, for ordered enumerations that consumers look up by name.
+// JSON object keys are strings, so elements must have a string form: string elements are used as they are, and numbers
+// are written as their JSON text, e.g. "1.5". It returns an error for elements JSON cannot represent, such as NaN. If
+// the set is empty an empty JSON object is returned.
+func (s *Ordered[M]) MarshalJSONObject() ([]byte, error) {
+	d := []byte{'{'}
+	for i, m := range s.elements() {
+		key, err := jsonObjectKey(m)
+		if err != nil {
+			return nil, fmt.Errorf("marshaling ordered set object: %w", err)
+		}
+		if i > 0 {
+			d = append(d, ',')
+		}
+		d = append(d, key...)
+		d = append(d, ':')
+		d = strconv.AppendInt(d, int64(i), 10)
+	}
+	return append(d, '}'), nil
+}
+
+// UnmarshalJSONObject unmarshals the set from a JSON object as written by MarshalJSONObject, ordering the elements by
+// their indexes; the order of the object's keys does not matter. The indexes must be exactly 0 through n-1 for an
+// object of n keys, and no two keys may be the same element (e.g. "1" and "1.0" for a float set). A JSON null leaves
+// the set empty. If the JSON is invalid, a key is not an element, or the indexes are not as required, it returns an
+// error and the set is left unchanged.
+func (s *Ordered[M]) UnmarshalJSONObject(d []byte) error {
+	var obj map[string]int
+	if err := json.Unmarshal(d, &obj); err != nil {
+		return fmt.Errorf("unmarshaling ordered set object: %w", err)
+	}
+	el := make([]M, len(obj))
+	seen := make([]bool, len(obj))
+	for key, i := range obj {
+		if i < 0 || i >= len(obj) || seen[i] {
+			return fmt.Errorf("unmarshaling ordered set object: index %d of %q is out of range or repeated", i, key)
+		}
+		m, err := parseJSONObjectKey[M](key)
+		if err != nil {
+			return fmt.Errorf("unmarshaling ordered set object: key %q: %w", key, err)
+		}
+		el[i], seen[i] = m, true
+	}
+	t := NewOrderedWith(el...)
+	if t.Cardinality() != len(el) {
+		return fmt.Errorf("unmarshaling ordered set object: keys repeat an element")
+	}
+	s.Clear()
+	AppendSeq[M](s, t.Iterator)
+	return nil
+}
+
+// jsonObjectKey returns m's form as a JSON object key: a JSON string of the string itself for string kinds, or of the
+// element's JSON text otherwise.
+func jsonObjectKey[M cmp.Ordered](m M) ([]byte, error) {
+	v := reflect.ValueOf(m)
+	if v.Kind() == reflect.String {
+		return json.Marshal(v.String())
+	}
+	d, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(d))
+}
+
+// parseJSONObjectKey is the inverse of jsonObjectKey, once the key has been decoded from its JSON string.
+func parseJSONObjectKey[M cmp.Ordered](key string) (M, error) {
+	var m M
+	v := reflect.ValueOf(&m).Elem()
+	if v.Kind() == reflect.String {
+		v.SetString(key)
+		return m, nil
+	}
+	err := json.Unmarshal([]byte(key), &m)
+	return m, err
+}
+
 // Scan implements the sql.Scanner interface. It scans the value from the database into the set. It expects a JSON array
 // of the elements in the set. If the JSON is invalid an error is returned. If the value is nil an empty set is
 // returned.
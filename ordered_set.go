@@ -2,6 +2,7 @@ package sets
 
 import (
 	"cmp"
+	"slices"
 )
 
 // OrderedSet is an extended set interface that implementations can implement to indicate that the set is ordered.
@@ -52,8 +53,36 @@ func EqualOrdered[K cmp.Ordered](a, b OrderedSet[K]) bool {
 	return true
 }
 
-// IsSorted returns true if the OrderedSet is sorted in ascending order. [cmp.Less] is used to compare elements.
+// EqualSliceOrdered returns true if the OrderedSet holds exactly the distinct elements of items, in the order of their
+// first occurrence in items, e.g. to assert in a test that a set matches an expected literal: repeats in items are
+// ignored, as adding items to an empty Ordered set would drop them. [cmp.Compare] is used to compare elements, as in
+// EqualOrdered. A nil set is treated as empty. See EqualSlice to ignore order.
+func EqualSliceOrdered[K cmp.Ordered](s OrderedSet[K], items []K) bool {
+	if s == nil || s.Cardinality() == 0 { // also catches typed nils, whose At would dereference them
+		return len(items) == 0
+	}
+	seen := make(map[K]struct{}, len(items))
+	var i int
+	for _, k := range items {
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		if v, ok := s.At(i); !ok || cmp.Compare(k, v) != 0 {
+			return false
+		}
+		i++
+	}
+	return i == s.Cardinality()
+}
+
+// IsSorted returns true if the OrderedSet is sorted in ascending order. [cmp.Less] is used to compare elements. It is
+// O(1) for a set that tracks its sorted state and is known to be sorted (e.g. an Ordered set after Sort), and O(N)
+// otherwise.
 func IsSorted[K cmp.Ordered](s OrderedSet[K]) bool {
+	if st, ok := s.(sortTracker); ok && st.knownSorted() {
+		return true
+	}
 	var prev K
 	for i, k := range s.Ordered {
 		if i != 0 && cmp.Less(k, prev) {
@@ -64,6 +93,12 @@ func IsSorted[K cmp.Ordered](s OrderedSet[K]) bool {
 	return true
 }
 
+// sortTracker is implemented by ordered set types that track whether they are known to be in ascending order, so that
+// IsSorted can skip its scan. knownSorted may return false for a sorted set, but never true for an unsorted one.
+type sortTracker interface {
+	knownSorted() bool
+}
+
 // Reverse returns a new OrderedSet with the elements in the reverse order of the original OrderedSet.
 // Always-sorted implementations (e.g. SortedSet) cannot hold elements out of ascending order, so
 // reversing one returns a set with the same ascending order as the original.
@@ -84,6 +119,63 @@ func Sorted[K cmp.Ordered](s OrderedSet[K]) OrderedSet[K] {
 	return out
 }
 
+// SortBy sorts the set in place in ascending order of the key derived from each element, e.g. a struct field for a
+// set of IDs that index into a table. The sort is stable: elements with equal keys keep their relative order. The key
+// function is called O(N log N) times, so it should be cheap. Sets whose order is fixed are left unchanged; for a
+// SortedSet, which is always in ascending element order, SortBy has no effect.
+func SortBy[M cmp.Ordered, K cmp.Ordered](s OrderedSet[M], key func(M) K) {
+	sortStable(s, func(a, b M) int {
+		return cmp.Compare(key(a), key(b))
+	})
+}
+
+// stableSorter is implemented by ordered set types that can stably reorder their elements in place more cheaply than
+// by clearing and re-adding them. SortBy uses it when available.
+type stableSorter[M cmp.Ordered] interface {
+	sortStableFunc(cmp func(M, M) int)
+}
+
+// sortStable stably sorts the set by cmp, in place when the set is a stableSorter and otherwise by clearing the set
+// and re-adding its elements in sorted order.
+func sortStable[M cmp.Ordered](s OrderedSet[M], cmp func(M, M) int) {
+	if ss, ok := s.(stableSorter[M]); ok {
+		ss.sortStableFunc(cmp)
+		return
+	}
+	elems := Elements(s)
+	slices.SortStableFunc(elems, cmp)
+	s.Clear()
+	AppendSeq(s, slices.Values(elems))
+}
+
+// Concat returns a new OrderedSet holding the elements of the sets in argument order: the elements of the first set in
+// its order, then those of the second that were not already added, and so on, so each element keeps the position of
+// its first occurrence across all the sets. This suits merging ranked lists where earlier lists take precedence. The
+// result is of the same underlying type as the first set and follows its rules, so a SortedSet result is in ascending
+// order rather than concatenated order, and a Bounded result keeps only its limit of elements. With no sets, or a nil
+// first set, the result is an *Ordered. Nil sets are treated as empty.
+func Concat[M cmp.Ordered](sets ...OrderedSet[M]) OrderedSet[M] {
+	var out OrderedSet[M]
+	if len(sets) == 0 || sets[0] == nil {
+		out = NewOrdered[M]()
+	} else {
+		out = sets[0].NewEmptyOrdered()
+	}
+	var n int
+	for _, s := range sets {
+		if s != nil {
+			n += s.Cardinality()
+		}
+	}
+	grow(out, n)
+	for _, s := range sets {
+		if s != nil {
+			AppendSeq(out, s.Iterator)
+		}
+	}
+	return out
+}
+
 // ReduceRight reduces the set from right to left using the given function. "initial" is the initial value of the
 // accumulator. The function is called with the accumulator and the element backwards. The result of the function is the
 // new accumulator value. The final accumulator value is returned.
@@ -111,3 +203,42 @@ func First[K cmp.Ordered](s OrderedSet[K]) (K, bool) {
 func Last[K cmp.Ordered](s OrderedSet[K]) (K, bool) {
 	return s.At(s.Cardinality() - 1)
 }
+
+// UnionPreferOrdered is like Union, but the result is ordered whenever either operand is: when a is not an OrderedSet
+// and b is, it returns Union(b, a), a new set of b's underlying type that adds a's elements to b's as that type places
+// them, e.g. appended in a's iteration order for an Ordered b, or in sorted position for a SortedSet. Otherwise it
+// returns Union(a, b), a set of a's type, which is ordered if a is.
+func UnionPreferOrdered[K cmp.Ordered](a, b Set[K]) Set[K] {
+	if _, ok := a.(OrderedSet[K]); !ok {
+		if _, ok := b.(OrderedSet[K]); ok {
+			return Union(b, a)
+		}
+	}
+	return Union(a, b)
+}
+
+// IndexBy returns the index of the first element, in order, whose key, as derived by the key function, equals v, or -1
+// if there is none; see ContainsBy. It is a linear scan, so IndexBy is O(N).
+func IndexBy[K cmp.Ordered, V comparable](s OrderedSet[K], v V, key func(K) V) int {
+	for i, k := range s.Ordered {
+		if key(k) == v {
+			return i
+		}
+	}
+	return -1
+}
+
+// ElementsOrdered returns the elements of the ordered set as a slice, in the set's order. Returns nil if the set is
+// empty. It is equivalent to Elements, but makes the ordering guarantee explicit in the signature for callers that
+// depend on it.
+func ElementsOrdered[K cmp.Ordered](s OrderedSet[K]) []K {
+	n := s.Cardinality()
+	if n == 0 {
+		return nil
+	}
+	out := make([]K, 0, n)
+	for _, k := range s.Ordered {
+		out = append(out, k)
+	}
+	return out
+}
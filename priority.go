@@ -0,0 +1,359 @@
+package sets
+
+import (
+	"cmp"
+	"container/heap"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"iter"
+)
+
+// PrioritySet is a set whose elements each carry a priority, for scheduling and similar uses that need both
+// de-duplication and priority order: PopHighest and PopLowest remove the element with the highest or lowest priority.
+// Priorities are float64s, compared as by cmp.Compare, so a NaN priority is lower than any other. The order of
+// elements with equal priorities is not defined. It is not safe for concurrent use; wrap it with NewLocked when
+// concurrency is needed.
+//
+// PrioritySet satisfies Set[M] for membership. Add gives a new element priority 0; use AddWithPriority to choose the
+// priority or change an element's priority. Pop is PopHighest. Iterator yields the elements in no particular order.
+//
+// It is backed by a map from element to entry and two binary heaps of the entries, one ordered each way, so the cost
+// of keeping the priority order is paid on every change:
+//   - Add, AddWithPriority, Remove, PopHighest, PopLowest: O(log N), against O(1) for Map
+//   - Contains, Priority: O(1)
+//   - Iterator: O(N)
+//
+// PrioritySet's zero value is not usable; create one with NewPrioritySet.
+type PrioritySet[M comparable] struct {
+	idx map[M]*priorityEntry[M]
+	lo  priorityHeap[M] // lowest priority at the root
+	hi  priorityHeap[M] // highest priority at the root
+}
+
+var _ Set[int] = new(PrioritySet[int])
+var _ driver.Valuer = new(PrioritySet[int])
+
+// priorityEntry is an element of a PrioritySet, its priority, and its position in each of the set's heaps.
+type priorityEntry[M comparable] struct {
+	m   M
+	p   float64
+	pos [2]int // indexed by priorityHeap.side
+}
+
+// priorityHeap is one of a PrioritySet's heaps of entries, implementing heap.Interface. Side 0 keeps the lowest
+// priority at the root and side 1 the highest; each records the positions of its entries in priorityEntry.pos[side].
+type priorityHeap[M comparable] struct {
+	items []*priorityEntry[M]
+	side  int
+}
+
+func (h *priorityHeap[M]) Len() int { return len(h.items) }
+
+func (h *priorityHeap[M]) Less(i, j int) bool {
+	if h.side == 0 {
+		return cmp.Less(h.items[i].p, h.items[j].p)
+	}
+	return cmp.Less(h.items[j].p, h.items[i].p)
+}
+
+func (h *priorityHeap[M]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].pos[h.side] = i
+	h.items[j].pos[h.side] = j
+}
+
+func (h *priorityHeap[M]) Push(x any) {
+	e := x.(*priorityEntry[M])
+	e.pos[h.side] = len(h.items)
+	h.items = append(h.items, e)
+}
+
+func (h *priorityHeap[M]) Pop() any {
+	e := h.items[len(h.items)-1]
+	h.items[len(h.items)-1] = nil
+	h.items = h.items[:len(h.items)-1]
+	return e
+}
+
+// NewPrioritySet returns an empty *PrioritySet[M].
+func NewPrioritySet[M comparable]() *PrioritySet[M] {
+	return &PrioritySet[M]{
+		idx: make(map[M]*priorityEntry[M]),
+		hi:  priorityHeap[M]{side: 1},
+	}
+}
+
+// NewPrioritySetFrom returns a new *PrioritySet[M] filled with the element and priority pairs from the sequence, e.g.
+// maps.All of a map from element to priority. When an element repeats, its last priority is kept.
+func NewPrioritySetFrom[M comparable](seq iter.Seq2[M, float64]) *PrioritySet[M] {
+	s := NewPrioritySet[M]()
+	for m, p := range seq {
+		s.AddWithPriority(m, p)
+	}
+	return s
+}
+
+// Contains returns true if the set contains the element.
+func (s *PrioritySet[M]) Contains(m M) bool {
+	if s == nil {
+		return false
+	}
+	_, ok := s.idx[m]
+	return ok
+}
+
+// Priority returns the element's priority. The second return value is false if the element is not in the set.
+func (s *PrioritySet[M]) Priority(m M) (float64, bool) {
+	if s == nil {
+		return 0, false
+	}
+	e, ok := s.idx[m]
+	if !ok {
+		return 0, false
+	}
+	return e.p, true
+}
+
+// Clear the set and returns the number of elements removed.
+func (s *PrioritySet[M]) Clear() int {
+	n := len(s.idx)
+	clear(s.idx)
+	clear(s.lo.items)
+	clear(s.hi.items)
+	s.lo.items, s.hi.items = s.lo.items[:0], s.hi.items[:0]
+	return n
+}
+
+// Drain removes all elements from the set and returns them in descending order of priority. Returns nil if the set is
+// empty.
+func (s *PrioritySet[M]) Drain() []M {
+	if len(s.idx) == 0 {
+		return nil
+	}
+	out := make([]M, 0, len(s.idx))
+	for m, ok := s.PopHighest(); ok; m, ok = s.PopHighest() {
+		out = append(out, m)
+	}
+	return out
+}
+
+// Add an element to the set with priority 0. Returns true if the element was added, false if it was already present,
+// in which case its priority is unchanged.
+func (s *PrioritySet[M]) Add(m M) bool {
+	if _, ok := s.idx[m]; ok {
+		return false
+	}
+	s.AddWithPriority(m, 0)
+	return true
+}
+
+// AddWithPriority adds an element to the set with the priority, or changes the element's priority if it is already
+// present. Returns true if the element was added, false if it was already present.
+func (s *PrioritySet[M]) AddWithPriority(m M, priority float64) bool {
+	if e, ok := s.idx[m]; ok {
+		e.p = priority
+		heap.Fix(&s.lo, e.pos[0])
+		heap.Fix(&s.hi, e.pos[1])
+		return false
+	}
+	e := &priorityEntry[M]{m: m, p: priority}
+	s.idx[m] = e
+	heap.Push(&s.lo, e)
+	heap.Push(&s.hi, e)
+	return true
+}
+
+// Merge adds all of other's elements to the set in place and returns the number that were not already present. When
+// other is also a *PrioritySet[M] the new elements keep their priorities from other; otherwise they get priority 0.
+// Elements already in the set keep their priorities.
+func (s *PrioritySet[M]) Merge(other Set[M]) int {
+	o, ok := other.(*PrioritySet[M])
+	if !ok || o == nil {
+		return AppendSeq[M](s, orEmpty(other).Iterator)
+	}
+	var n int
+	for m, e := range o.idx {
+		if _, ok := s.idx[m]; !ok {
+			s.AddWithPriority(m, e.p)
+			n++
+		}
+	}
+	return n
+}
+
+// RemoveAll removes all of other's elements from the set in place and returns the number removed. It is the in-place
+// form of Difference, avoiding the copy Difference makes.
+func (s *PrioritySet[M]) RemoveAll(other Set[M]) int {
+	if Set[M](s) == other {
+		return s.Clear()
+	}
+	return RemoveSeq[M](s, orEmpty(other).Iterator)
+}
+
+// Keep removes every element that is not in other from the set in place and returns the number removed. It is the
+// in-place form of Intersection, avoiding the copy Intersection makes.
+func (s *PrioritySet[M]) Keep(other Set[M]) int {
+	if Set[M](s) == other {
+		return 0
+	}
+	return keepOnly[M](s, other)
+}
+
+// Remove an element from the set. Returns true if the element was removed, false if it was not present.
+func (s *PrioritySet[M]) Remove(m M) bool {
+	e, ok := s.idx[m]
+	if !ok {
+		return false
+	}
+	s.remove(e)
+	return true
+}
+
+// remove takes the entry out of the index map and both heaps.
+func (s *PrioritySet[M]) remove(e *priorityEntry[M]) {
+	delete(s.idx, e.m)
+	heap.Remove(&s.lo, e.pos[0])
+	heap.Remove(&s.hi, e.pos[1])
+}
+
+// PopHighest removes and returns the element with the highest priority. If the set is empty, it returns the zero value
+// of M and false.
+func (s *PrioritySet[M]) PopHighest() (M, bool) {
+	return s.popRoot(&s.hi)
+}
+
+// PopLowest removes and returns the element with the lowest priority. If the set is empty, it returns the zero value of
+// M and false.
+func (s *PrioritySet[M]) PopLowest() (M, bool) {
+	return s.popRoot(&s.lo)
+}
+
+// popRoot removes and returns the element at the root of h, one of the set's heaps.
+func (s *PrioritySet[M]) popRoot(h *priorityHeap[M]) (M, bool) {
+	if len(h.items) == 0 {
+		var zero M
+		return zero, false
+	}
+	e := h.items[0]
+	s.remove(e)
+	return e.m, true
+}
+
+// Pop removes and returns the element with the highest priority, as PopHighest does. If the set is empty, it returns
+// the zero value of M and false.
+func (s *PrioritySet[M]) Pop() (M, bool) {
+	return s.PopHighest()
+}
+
+// Cardinality returns the number of elements in the set.
+func (s *PrioritySet[M]) Cardinality() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.idx)
+}
+
+// Iterator yields all elements in the set, in no particular order. Changing the set while iterating is undefined.
+func (s *PrioritySet[M]) Iterator(yield func(M) bool) {
+	if s == nil {
+		return
+	}
+	for _, e := range s.lo.items {
+		if !yield(e.m) {
+			return
+		}
+	}
+}
+
+// Clone returns a copy of the set, priorities included. The underlying type is the same as the original set.
+func (s *PrioritySet[M]) Clone() Set[M] {
+	c := NewPrioritySet[M]()
+	if s == nil {
+		return c
+	}
+	c.lo.items = make([]*priorityEntry[M], len(s.lo.items))
+	c.hi.items = make([]*priorityEntry[M], len(s.hi.items))
+	for i, e := range s.lo.items {
+		ce := *e
+		c.idx[e.m] = &ce
+		c.lo.items[i] = &ce
+	}
+	for i, e := range s.hi.items {
+		c.hi.items[i] = c.idx[e.m]
+	}
+	return c
+}
+
+// NewEmpty returns a new empty *PrioritySet[M].
+func (s *PrioritySet[M]) NewEmpty() Set[M] {
+	return NewPrioritySet[M]()
+}
+
+// priorities returns a map from each element to its priority.
+func (s *PrioritySet[M]) priorities() map[M]float64 {
+	out := make(map[M]float64, len(s.idx))
+	for m, e := range s.idx {
+		out[m] = e.p
+	}
+	return out
+}
+
+// String returns a string representation of the set. It returns a string of the form
+// PrioritySet[T](map[<element>:<priority> ...]).
+func (s *PrioritySet[M]) String() string {
+	var m M
+	return fmt.Sprintf("PrioritySet[%T](%v)", m, s.priorities())
+}
+
+// prioritizedJSON is the JSON form of an element of a PrioritySet.
+type prioritizedJSON[M comparable] struct {
+	Element  M       `json:"element"`
+	Priority float64 `json:"priority"`
+}
+
+// MarshalJSON implements json.Marshaler. It returns a JSON array of {"element": ..., "priority": ...} objects in
+// descending order of priority, so the priorities survive a round trip. If the set is empty, it returns an empty JSON
+// array. A NaN or infinite priority cannot be encoded and returns an error.
+func (s *PrioritySet[M]) MarshalJSON() ([]byte, error) {
+	if len(s.idx) == 0 {
+		return []byte("[]"), nil
+	}
+	c := s.Clone().(*PrioritySet[M])
+	out := make([]prioritizedJSON[M], 0, len(s.idx))
+	for _, m := range c.Drain() {
+		out = append(out, prioritizedJSON[M]{m, s.idx[m].p})
+	}
+	d, err := json.Marshal(out)
+	if err != nil {
+		return d, fmt.Errorf("marshaling priority set: %w", err)
+	}
+	return d, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It expects a JSON array of {"element": ..., "priority": ...} objects, as
+// written by MarshalJSON; a missing priority is 0, and when an element repeats its last priority is kept. If the JSON
+// is invalid, it returns an error and the set is left unchanged.
+func (s *PrioritySet[M]) UnmarshalJSON(d []byte) error {
+	var um []prioritizedJSON[M]
+	if err := json.Unmarshal(d, &um); err != nil {
+		return fmt.Errorf("unmarshaling priority set: %w", err)
+	}
+	s.Clear()
+	for _, e := range um {
+		s.AddWithPriority(e.Element, e.Priority)
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface. It returns the JSON representation of the set.
+func (s *PrioritySet[M]) Value() (driver.Value, error) {
+	return s.MarshalJSON()
+}
+
+// Scan implements the sql.Scanner interface. It scans the value from the database into the set. It expects a JSON
+// array of {"element": ..., "priority": ...} objects, as written by MarshalJSON. If the JSON is invalid an error is
+// returned. If the value is nil an empty set is returned.
+func (s *PrioritySet[M]) Scan(src any) error {
+	return scanValue[M](src, s.Clear, s.UnmarshalJSON)
+}
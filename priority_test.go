@@ -0,0 +1,152 @@
+package sets
+
+import (
+	"encoding/json"
+	"maps"
+	"math"
+	"slices"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// TestPrioritySet_Model checks PrioritySet against a map of priorities across random AddWithPriority/Add/Remove/
+// PopHighest/PopLowest sequences, and that Clone is independent of the original.
+func TestPrioritySet_Model(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(t *rapid.T) {
+		s := NewPrioritySet[int]()
+		model := make(map[int]float64)
+
+		// extremes returns the elements with the lowest and highest priority in the model
+		extremes := func() (lo, hi []int) {
+			for m, p := range model {
+				switch {
+				case len(lo) == 0 || p < model[lo[0]]:
+					lo = []int{m}
+				case p == model[lo[0]]:
+					lo = append(lo, m)
+				}
+				switch {
+				case len(hi) == 0 || p > model[hi[0]]:
+					hi = []int{m}
+				case p == model[hi[0]]:
+					hi = append(hi, m)
+				}
+			}
+			return lo, hi
+		}
+
+		steps := rapid.IntRange(1, 200).Draw(t, "Steps")
+		for range steps {
+			v := rapid.IntRange(-10, 10).Draw(t, "Value")
+			_, present := model[v]
+			switch rapid.IntRange(0, 4).Draw(t, "Op") {
+			case 0:
+				p := float64(rapid.IntRange(-5, 5).Draw(t, "Priority"))
+				if s.AddWithPriority(v, p) == present {
+					t.Fatalf("AddWithPriority(%d, %v): expected added=%v", v, p, !present)
+				}
+				model[v] = p
+			case 1:
+				if s.Add(v) == present {
+					t.Fatalf("Add(%d): expected added=%v", v, !present)
+				}
+				if !present {
+					model[v] = 0
+				}
+			case 2:
+				if s.Remove(v) != present {
+					t.Fatalf("Remove(%d): expected removed=%v", v, present)
+				}
+				delete(model, v)
+			case 3:
+				_, hi := extremes()
+				m, ok := s.PopHighest()
+				if ok != (len(hi) > 0) || ok && !slices.Contains(hi, m) {
+					t.Fatalf("PopHighest() = %d, %v, want one of %v", m, ok, hi)
+				}
+				delete(model, m)
+			case 4:
+				lo, _ := extremes()
+				m, ok := s.PopLowest()
+				if ok != (len(lo) > 0) || ok && !slices.Contains(lo, m) {
+					t.Fatalf("PopLowest() = %d, %v, want one of %v", m, ok, lo)
+				}
+				delete(model, m)
+			}
+
+			if s.Cardinality() != len(model) {
+				t.Fatalf("Cardinality() = %d, want %d", s.Cardinality(), len(model))
+			}
+			for m, p := range model {
+				if got, ok := s.Priority(m); !ok || got != p {
+					t.Fatalf("Priority(%d) = %v, %v, want %v", m, got, ok, p)
+				}
+			}
+		}
+
+		c := s.Clone().(*PrioritySet[int])
+		c.AddWithPriority(100, 100)
+		if s.Contains(100) {
+			t.Fatal("Clone shares state with the original")
+		}
+		drained := s.Drain()
+		if len(drained) != len(model) || s.Cardinality() != 0 {
+			t.Fatalf("Drain() = %v, want %d elements and an empty set", drained, len(model))
+		}
+		if !slices.IsSortedFunc(drained, func(a, b int) int { return int(model[b] - model[a]) }) {
+			t.Fatalf("Drain() = %v, not in descending order of priority %v", drained, model)
+		}
+		if got, _ := c.PopHighest(); got != 100 {
+			t.Fatalf("clone PopHighest() = %d, want 100", got)
+		}
+	})
+}
+
+func TestPrioritySet_NaN(t *testing.T) {
+	t.Parallel()
+
+	s := NewPrioritySetFrom(maps.All(map[string]float64{"nan": math.NaN(), "low": math.Inf(-1), "high": 1}))
+	if m, _ := s.PopLowest(); m != "nan" {
+		t.Fatalf("PopLowest() = %q, want the NaN priority first", m)
+	}
+	if m, _ := s.Pop(); m != "high" {
+		t.Fatalf("Pop() = %q, want high", m)
+	}
+}
+
+func TestPrioritySet_JSON(t *testing.T) {
+	t.Parallel()
+
+	s := NewPrioritySet[string]()
+	s.AddWithPriority("b", 2)
+	s.AddWithPriority("a", 1)
+	s.AddWithPriority("c", 3)
+	d, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"element":"c","priority":3},{"element":"b","priority":2},{"element":"a","priority":1}]`
+	if string(d) != want {
+		t.Fatalf("MarshalJSON() = %s, want %s", d, want)
+	}
+
+	got := NewPrioritySet[string]()
+	if err := got.Scan(d); err != nil {
+		t.Fatal(err)
+	}
+	if !maps.Equal(got.priorities(), s.priorities()) {
+		t.Fatalf("round trip = %v, want %v", got, s)
+	}
+	if err := got.UnmarshalJSON([]byte(`[1]`)); err == nil || got.Cardinality() != 3 {
+		t.Fatalf("UnmarshalJSON of invalid JSON: err = %v, set = %v", err, got)
+	}
+	if v, err := NewPrioritySet[int]().Value(); err != nil || string(v.([]byte)) != "[]" {
+		t.Fatalf("Value() of an empty set = %s, %v", v, err)
+	}
+	if got := s.String(); got != "PrioritySet[string](map[a:1 b:2 c:3])" {
+		t.Fatalf("String() = %s", got)
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"maps"
+	"math"
 	"slices"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -363,6 +365,23 @@ func (sm *SetStateMachine) Clear(t *rapid.T) {
 	sm.stateO = nil
 }
 
+func (sm *SetStateMachine) Drain(t *rapid.T) {
+	want := Elements(sm.set)
+	got := Drain(sm.set)
+	if _, ordered := sm.set.(OrderedSet[int]); !ordered {
+		slices.Sort(want)
+		slices.Sort(got)
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("Drain() = %v, want %v", got, want)
+	}
+	if sm.set.Cardinality() != 0 {
+		t.Fatalf("expected an empty set after Drain, got %d elements", sm.set.Cardinality())
+	}
+	sm.stateI = make(map[int]int)
+	sm.stateO = nil
+}
+
 func (sm *SetStateMachine) Check(t *rapid.T) {
 	t.Logf("set: %#v\n", sm.set)
 	t.Logf("stateI: %#v\n", sm.stateI)
@@ -618,6 +637,262 @@ func TestSync_Concurrency(t *testing.T) {
 	)
 }
 
+// TestLocked_AddAllRemoveAll checks the batch methods' counts and that concurrent readers never
+// observe a partially applied batch.
+// TestLocked_WriteDuringIteration pins the shared iteration guarantee of Locked and LockedOrdered: a writer in another
+// goroutine completes while an iteration is paused inside its yield callback, and the iteration sees the set as it was
+// when it started.
+func TestLocked_WriteDuringIteration(t *testing.T) {
+	t.Parallel()
+
+	for name, set := range map[string]Set[int]{
+		"Locked":        NewLockedWith(1, 2, 3),
+		"LockedOrdered": NewLockedOrderedWith(1, 2, 3),
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var seen []int
+			for v := range set.Iterator {
+				if len(seen) == 0 {
+					written := make(chan struct{})
+					go func() {
+						defer close(written)
+						set.Add(4)
+						set.Remove(2)
+					}()
+					select {
+					case <-written:
+					case <-time.After(5 * time.Second):
+						t.Fatal("writer blocked by an in-progress iteration")
+					}
+				}
+				seen = append(seen, v)
+			}
+
+			slices.Sort(seen)
+			if !slices.Equal(seen, []int{1, 2, 3}) {
+				t.Fatalf("iteration saw %v, want the starting contents [1 2 3]", seen)
+			}
+			if got := slices.Sorted(set.Iterator); !slices.Equal(got, []int{1, 3, 4}) {
+				t.Fatalf("after iteration the set holds %v, want [1 3 4]", got)
+			}
+		})
+	}
+}
+
+// TestLocked_DrainAtomic checks that Drain takes everything under one lock: with a writer adding disjoint batches
+// atomically via AddAll, every drain must return whole batches, and together the drains return every element.
+func TestLocked_DrainAtomic(t *testing.T) {
+	t.Parallel()
+
+	for name, set := range map[string]interface {
+		Set[int]
+		AddAll(...int) int
+		Drain() []int
+	}{
+		"Locked":        NewLocked[int](),
+		"LockedOrdered": NewLockedOrdered[int](),
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			const batch, batches = 50, 200
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				for i := range batches {
+					b := make([]int, batch)
+					for j := range b {
+						b[j] = i*batch + j
+					}
+					set.AddAll(b...)
+				}
+			}()
+			var total int
+			for finished := false; !finished; {
+				select {
+				case <-done:
+					finished = true
+				default:
+				}
+				n := len(set.Drain())
+				if n%batch != 0 {
+					t.Fatalf("Drain returned %d elements, a partial batch", n)
+				}
+				total += n
+			}
+			if total != batch*batches {
+				t.Fatalf("drained %d elements in total, want %d", total, batch*batches)
+			}
+		})
+	}
+}
+
+func TestLocked_AddAllRemoveEach(t *testing.T) {
+	t.Parallel()
+
+	for name, set := range map[string]interface {
+		Set[int]
+		AddAll(...int) int
+		RemoveEach(...int) int
+	}{
+		"Locked":        NewLocked[int](),
+		"LockedOrdered": NewLockedOrdered[int](),
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if n := set.AddAll(1, 2, 2, 3); n != 3 {
+				t.Fatalf("AddAll added %d, want 3", n)
+			}
+			if n := set.RemoveEach(3, 4); n != 1 {
+				t.Fatalf("RemoveEach removed %d, want 1", n)
+			}
+			set.Clear()
+
+			batch := genInts(100)
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				for range 100 {
+					set.AddAll(batch...)
+					set.RemoveEach(batch...)
+				}
+			}()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				if n := set.Cardinality(); n != 0 && n != len(batch) {
+					t.Fatalf("observed a partial batch: cardinality %d", n)
+				}
+			}
+		})
+	}
+}
+
+// TestLocked_RemoveAllConcurrent checks that the locked wrappers' RemoveAll is atomic with respect to readers, and that
+// two locked sets removing each other's elements concurrently do not deadlock.
+func TestLocked_RemoveAllConcurrent(t *testing.T) {
+	t.Parallel()
+
+	type lockedSet interface {
+		Set[int]
+		AddAll(...int) int
+		RemoveAll(Set[int]) int
+	}
+	for name, newSet := range map[string]func(...int) lockedSet{
+		"Locked":        func(m ...int) lockedSet { return NewLockedWith(m...) },
+		"LockedOrdered": func(m ...int) lockedSet { return NewLockedOrderedWith(m...) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			batch := genInts(100)
+			a, b := newSet(batch...), newSet(batch...)
+			var wg sync.WaitGroup
+			for _, pair := range [][2]lockedSet{{a, b}, {b, a}} {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for range 100 {
+						pair[0].RemoveAll(pair[1])
+						pair[0].AddAll(batch...)
+					}
+				}()
+			}
+			wg.Wait()
+
+			s, other := newSet(), NewWith(batch...)
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				for range 100 {
+					s.AddAll(batch...)
+					s.RemoveAll(other)
+				}
+			}()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				if n := s.Cardinality(); n != 0 && n != len(batch) {
+					t.Fatalf("observed a partial removal: cardinality %d", n)
+				}
+			}
+		})
+	}
+}
+
+// symmetricDifferenceReference is the original two-walk SymmetricDifference, kept as the
+// reference model for the larger/smaller toggle implementation.
+func symmetricDifferenceReference[K comparable](a, b Set[K]) Set[K] {
+	c := a.NewEmpty()
+	for k := range a.Iterator {
+		if !b.Contains(k) {
+			c.Add(k)
+		}
+	}
+	for k := range b.Iterator {
+		if !a.Contains(k) {
+			c.Add(k)
+		}
+	}
+	return c
+}
+
+func TestSymmetricDifference_MatchesReference(t *testing.T) {
+	t.Parallel()
+
+	impls := map[string]func() Set[int]{
+		"Map":     func() Set[int] { return New[int]() },
+		"Ordered": func() Set[int] { return NewOrdered[int]() },
+		"SyncMap": func() Set[int] { return NewSyncMap[int]() },
+		"Locked":  func() Set[int] { return NewLocked[int]() },
+		"Bag":     func() Set[int] { return NewBag[int]() },
+	}
+	for name, newSet := range impls {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			rapid.Check(t, func(t *rapid.T) {
+				a, b := newSet(), newSet()
+				AppendSeq(a, slices.Values(rapid.SliceOf(rapid.IntRange(0, 50)).Draw(t, "a")))
+				AppendSeq(b, slices.Values(rapid.SliceOf(rapid.IntRange(0, 50)).Draw(t, "b")))
+				got, want := SymmetricDifference(a, b), symmetricDifferenceReference(a, b)
+				if !Equal(got, want) {
+					t.Fatalf("SymmetricDifference = %v, want %v", Elements(got), Elements(want))
+				}
+				if _, ok := got.(*Ordered[int]); ok && !slices.Equal(Elements(got), Elements(want)) {
+					t.Fatalf("SymmetricDifference order = %v, want %v", Elements(got), Elements(want))
+				}
+				if bag, ok := got.(*Bag[int]); ok && bag.Total() != bag.Cardinality() {
+					t.Fatalf("Bag result has repeated elements: %v", bag)
+				}
+			})
+		})
+	}
+}
+
+// TestSymmetricDifference_Order checks that ordered results keep a's remaining elements first, in a's order, followed
+// by b's extras, whichever set is larger.
+func TestSymmetricDifference_Order(t *testing.T) {
+	t.Parallel()
+
+	got := SymmetricDifference[int](NewOrderedWith(1, 2), NewOrderedWith(3, 4, 5, 2))
+	if want := []int{1, 3, 4, 5}; !slices.Equal(Elements(got), want) {
+		t.Fatalf("SymmetricDifference = %v, want %v", Elements(got), want)
+	}
+	got = SymmetricDifference[int](NewOrderedWith(5, 1, 2), NewWith(2, 3, 4, 6, 7, 8))
+	if el := Elements(got); len(el) != 7 || !slices.Equal(el[:2], []int{5, 1}) {
+		t.Fatalf("SymmetricDifference = %v, want [5 1] followed by b's extras", el)
+	}
+}
+
 func TestOrdered_Remove(t *testing.T) {
 	t.Parallel()
 	s := NewOrdered[int]()
@@ -693,9 +968,17 @@ func TestOrdered_AtIndex_AfterRemoval(t *testing.T) {
 		}
 	}
 
+	// Verify negative indexes count back from the end
+	for i := range want {
+		got, ok := s.At(i - len(want))
+		if !ok || got != want[i] {
+			t.Fatalf("At(%d): expected (%d, true), got (%d, %v)", i-len(want), want[i], got, ok)
+		}
+	}
+
 	// Verify At out of bounds
-	if _, ok := s.At(-1); ok {
-		t.Fatal("At(-1): expected ok=false")
+	if _, ok := s.At(-len(want) - 1); ok {
+		t.Fatalf("At(%d): expected ok=false", -len(want)-1)
 	}
 	if _, ok := s.At(len(want)); ok {
 		t.Fatalf("At(%d): expected ok=false", len(want))
@@ -731,6 +1014,392 @@ func TestOrdered_AtIndex_AfterRemoval(t *testing.T) {
 	}
 }
 
+func TestOrdered_RemoveSeqBatch(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		remove []int
+	}{
+		{"small batch", []int{3, 7}},
+		{"large batch", []int{0, 2, 4, 6, 8, 10, 12, 14, 16, 18, 1, 5}},
+		{"everything", genInts(20)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewOrdered[int]()
+			var want []int
+			for i := range 20 {
+				s.Add(i)
+				if !slices.Contains(tc.remove, i) {
+					want = append(want, i)
+				}
+			}
+			// duplicates and absent elements must not be counted
+			if n := RemoveSeq(s, slices.Values(append(slices.Clone(tc.remove), tc.remove[0], 100))); n != len(tc.remove) {
+				t.Fatalf("RemoveSeq removed %d, want %d", n, len(tc.remove))
+			}
+			if got := slices.Collect(s.Iterator); !slices.Equal(got, want) {
+				t.Fatalf("after RemoveSeq got %v, want %v", got, want)
+			}
+			for i, v := range want {
+				if got, _ := s.At(i); got != v {
+					t.Fatalf("At(%d) = %d, want %d", i, got, v)
+				}
+				if got := s.Index(v); got != i {
+					t.Fatalf("Index(%d) = %d, want %d", v, got, i)
+				}
+			}
+			s.Compact()
+			s.Add(100)
+			if got := s.Index(100); got != len(want) {
+				t.Fatalf("Index(100) after Compact = %d, want %d", got, len(want))
+			}
+		})
+	}
+}
+
+// TestOrdered_MoveToFrontBack checks MoveToFront/MoveToBack/Rotate/Swap against a slice model, with removals
+// interleaved so moves also run over slot arrays that contain gaps.
+func TestOrdered_MoveToFrontBack(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(t *rapid.T) {
+		s := NewOrdered[int]()
+		var model []int
+		steps := rapid.IntRange(1, 100).Draw(t, "Steps")
+		for range steps {
+			v := rapid.IntRange(0, 15).Draw(t, "Value")
+			i := slices.Index(model, v)
+			switch rapid.IntRange(0, 5).Draw(t, "Op") {
+			case 0:
+				if s.Add(v) {
+					model = append(model, v)
+				}
+			case 1:
+				if s.Remove(v) {
+					model = slices.Delete(model, i, i+1)
+				}
+			case 2:
+				if s.MoveToFront(v) != (i >= 0) {
+					t.Fatalf("MoveToFront(%d): expected %v", v, i >= 0)
+				}
+				if i >= 0 {
+					model = slices.Insert(slices.Delete(model, i, i+1), 0, v)
+				}
+			case 3:
+				if s.MoveToBack(v) != (i >= 0) {
+					t.Fatalf("MoveToBack(%d): expected %v", v, i >= 0)
+				}
+				if i >= 0 {
+					model = append(slices.Delete(model, i, i+1), v)
+				}
+			case 4:
+				n := rapid.IntRange(-40, 40).Draw(t, "Rotate")
+				s.Rotate(n)
+				if len(model) > 0 {
+					k := ((n % len(model)) + len(model)) % len(model)
+					model = append(model[k:], model[:k]...)
+				}
+			case 5:
+				a := rapid.IntRange(-20, 20).Draw(t, "SwapI")
+				b := rapid.IntRange(-20, 20).Draw(t, "SwapJ")
+				n := len(model)
+				ok := a >= -n && a < n && b >= -n && b < n
+				if s.Swap(a, b) != ok {
+					t.Fatalf("Swap(%d, %d): expected %v", a, b, ok)
+				}
+				if ok {
+					a, b = (a+n)%n, (b+n)%n
+					model[a], model[b] = model[b], model[a]
+				}
+			}
+			if got := slices.Collect(s.Iterator); !slices.Equal(got, model) {
+				t.Fatalf("got %v, want %v", got, model)
+			}
+			for i, v := range model {
+				if got, _ := s.At(i); got != v {
+					t.Fatalf("At(%d) = %d, want %d", i, got, v)
+				}
+				if got := s.Index(v); got != i {
+					t.Fatalf("Index(%d) = %d, want %d", v, got, i)
+				}
+			}
+		}
+	})
+}
+
+func TestLockedOrdered_MoveToFrontBack(t *testing.T) {
+	t.Parallel()
+
+	s := NewLockedOrderedWith(1, 2, 3)
+	if !s.MoveToFront(3) || !s.MoveToBack(1) || s.MoveToFront(4) {
+		t.Fatal("unexpected MoveToFront/MoveToBack result")
+	}
+	if got := slices.Collect(s.Iterator); !slices.Equal(got, []int{3, 2, 1}) {
+		t.Fatalf("got %v, want [3 2 1]", got)
+	}
+	if !s.Swap(0, -1) || s.Swap(0, 3) {
+		t.Fatal("unexpected Swap result")
+	}
+	if got := slices.Collect(s.Iterator); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Fatalf("got %v, want [1 2 3]", got)
+	}
+
+	// a SortedSet's order is fixed, so it cannot be moved
+	sorted := NewLockedOrderedWrapping[int](NewSortedSetWith(1, 2)).(*LockedOrdered[int])
+	if sorted.MoveToFront(2) || sorted.MoveToBack(1) || sorted.Swap(0, 1) {
+		t.Fatal("moved an element of a wrapped SortedSet")
+	}
+}
+
+func TestOrdered_IndexFunc(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(t *rapid.T) {
+		vals := rapid.SliceOf(rapid.IntRange(0, 50)).Draw(t, "Values")
+		removed := rapid.SliceOf(rapid.IntRange(0, 50)).Draw(t, "Removed")
+		d := rapid.IntRange(1, 7).Draw(t, "Divisor")
+		f := func(v int) bool { return v%d == 0 }
+
+		for _, s := range []interface {
+			OrderedSet[int]
+			IndexFunc(func(int) bool) int
+		}{NewOrdered[int](), NewLockedOrdered[int](), NewBounded[int](10)} {
+			AppendSeq(s, slices.Values(vals))
+			RemoveSeq(s, slices.Values(removed)) // leaves gaps in Ordered's backing storage
+			want := slices.IndexFunc(Elements(s), f)
+			if got := s.IndexFunc(f); got != want {
+				t.Fatalf("%T.IndexFunc() = %d, want %d", s, got, want)
+			}
+			if want >= 0 {
+				if v, _ := s.At(want); !f(v) {
+					t.Fatalf("%T.At(IndexFunc()) = %d does not satisfy the predicate", s, v)
+				}
+			}
+		}
+	})
+}
+
+// TestContainsBy checks ContainsBy and IndexBy against scanning the elements for a matching key.
+func TestContainsBy(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(t *rapid.T) {
+		vals := rapid.SliceOf(rapid.IntRange(0, 50)).Draw(t, "Values")
+		removed := rapid.SliceOf(rapid.IntRange(0, 50)).Draw(t, "Removed")
+		d := rapid.IntRange(1, 7).Draw(t, "Divisor")
+		v := rapid.IntRange(0, 7).Draw(t, "Key")
+		key := func(k int) int { return k % d }
+
+		for _, s := range []OrderedSet[int]{NewOrdered[int](), NewLockedOrdered[int](), NewSortedSet[int]()} {
+			AppendSeq(s, slices.Values(vals))
+			RemoveSeq(s, slices.Values(removed)) // leaves gaps in Ordered's backing storage
+			want := slices.IndexFunc(Elements[int](s), func(k int) bool { return key(k) == v })
+			if got := IndexBy(s, v, key); got != want {
+				t.Fatalf("%T: IndexBy(%d) = %d, want %d", s, v, got, want)
+			}
+			if got := ContainsBy[int](s, v, key); got != (want >= 0) {
+				t.Fatalf("%T: ContainsBy(%d) = %v, want %v", s, v, got, want >= 0)
+			}
+		}
+	})
+
+	type user struct {
+		id   int
+		name string
+	}
+	users := NewWith(user{1, "ada"}, user{2, "bob"})
+	name := func(u user) string { return u.name }
+	if !ContainsBy[user](users, "bob", name) || ContainsBy[user](users, "eve", name) || ContainsBy[user](nil, "bob", name) {
+		t.Fatal("unexpected ContainsBy result on a set of structs")
+	}
+}
+
+func TestSortStable(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(t *rapid.T) {
+		vals := rapid.SliceOf(rapid.IntRange(0, 50)).Draw(t, "Values")
+		removed := rapid.SliceOf(rapid.IntRange(0, 50)).Draw(t, "Removed")
+
+		for _, s := range []interface {
+			OrderedSet[int]
+			SortStable()
+		}{
+			NewOrdered[int](),
+			NewLockedOrdered[int](),
+			NewLockedOrderedWrapping[int](NewBounded[int](20)).(*LockedOrdered[int]),
+			NewLockedOrderedWrapping[int](plainOrdered[int]{NewOrdered[int]()}).(*LockedOrdered[int]),
+		} {
+			AppendSeq(s, slices.Values(vals))
+			RemoveSeq(s, slices.Values(removed))
+			want := slices.Sorted(s.Iterator)
+
+			s.SortStable()
+			if got := Elements[int](s); !slices.Equal(got, want) {
+				t.Fatalf("%T: SortStable = %v, want %v", s, got, want)
+			}
+			for i, v := range want {
+				if s.Index(v) != i {
+					t.Fatalf("%T: Index(%d) = %d after SortStable, want %d", s, v, s.Index(v), i)
+				}
+			}
+			if !IsSorted[int](s) {
+				t.Fatalf("%T: IsSorted = false after SortStable", s)
+			}
+		}
+	})
+
+	// NaNs compare equal to each other, so they keep their insertion order; distinct payloads tell them apart
+	nan1, nan2 := math.Float64frombits(0x7ff8000000000001), math.Float64frombits(0x7ff8000000000002)
+	s := NewOrderedWith(2, nan2, 1, nan1)
+	s.SortStable()
+	got := slices.Collect(func(yield func(uint64) bool) {
+		for v := range s.Iterator {
+			if !yield(math.Float64bits(v)) {
+				return
+			}
+		}
+	})
+	want := []uint64{math.Float64bits(nan2), math.Float64bits(nan1), math.Float64bits(1), math.Float64bits(2)}
+	if !slices.Equal(got, want) {
+		t.Fatalf("SortStable with NaNs = %x, want %x", got, want)
+	}
+}
+
+func TestSortBy(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(t *rapid.T) {
+		vals := rapid.SliceOf(rapid.IntRange(0, 50)).Draw(t, "Values")
+		removed := rapid.SliceOf(rapid.IntRange(0, 50)).Draw(t, "Removed")
+		d := rapid.IntRange(1, 7).Draw(t, "Divisor")
+		key := func(v int) int { return v % d }
+
+		for _, s := range []OrderedSet[int]{
+			NewOrdered[int](),
+			NewLockedOrdered[int](),
+			NewBounded[int](20),
+			NewLockedOrderedWrapping[int](plainOrdered[int]{NewOrdered[int]()}), // no in-place sort: clear and re-add
+		} {
+			AppendSeq(s, slices.Values(vals))
+			RemoveSeq(s, slices.Values(removed))
+			want := Elements(s)
+			slices.SortStableFunc(want, func(a, b int) int { return key(a) - key(b) })
+
+			SortBy(s, key)
+			if got := Elements(s); !slices.Equal(got, want) {
+				t.Fatalf("%T: SortBy = %v, want %v", s, got, want)
+			}
+			for i, v := range want {
+				if s.Index(v) != i {
+					t.Fatalf("%T: Index(%d) = %d after SortBy, want %d", s, v, s.Index(v), i)
+				}
+			}
+		}
+
+		sorted := NewSortedSetWith(vals...)
+		SortBy(sorted, key)
+		if !IsSorted[int](sorted) || sorted.Cardinality() != NewWith(vals...).Cardinality() {
+			t.Fatalf("SortBy changed a SortedSet: %v", sorted)
+		}
+	})
+}
+
+func TestOrdered_AddSorted(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(t *rapid.T) {
+		s := NewOrdered[int]()
+		model := NewSortedSet[int]()
+
+		steps := rapid.IntRange(1, 100).Draw(t, "Steps")
+		for range steps {
+			v := rapid.IntRange(-30, 30).Draw(t, "Value")
+			if rapid.IntRange(0, 3).Draw(t, "Op") == 0 {
+				if s.Remove(v) != model.Remove(v) {
+					t.Fatalf("Remove(%d) disagrees with the model", v)
+				}
+				continue
+			}
+			if s.AddSorted(v) != model.Add(v) {
+				t.Fatalf("AddSorted(%d) disagrees with the model", v)
+			}
+			if !EqualOrdered[int](s, model) {
+				t.Fatalf("after AddSorted(%d): %v, want %v", v, Elements(s), Elements(model))
+			}
+			for i, w := range s.Ordered {
+				if s.Index(w) != i {
+					t.Fatalf("Index(%d) = %d, want %d", w, s.Index(w), i)
+				}
+			}
+		}
+	})
+}
+
+func TestLockedOrdered_AddSorted(t *testing.T) {
+	t.Parallel()
+
+	for _, inner := range []OrderedSet[int]{NewOrderedWith(1, 3, 5), NewSortedSetWith(1, 3, 5)} {
+		s := NewLockedOrderedWrapping(inner).(*LockedOrdered[int])
+		if !s.AddSorted(4) || !s.AddSorted(0) || s.AddSorted(3) {
+			t.Fatalf("%T: unexpected AddSorted result", inner)
+		}
+		if got := Elements[int](s); !slices.Equal(got, []int{0, 1, 3, 4, 5}) {
+			t.Fatalf("%T: got %v, want [0 1 3 4 5]", inner, got)
+		}
+	}
+
+	// an inner set without AddSorted is added to and, as it was sorted, sorted again
+	plain := NewLockedOrderedWrapping[int](plainOrdered[int]{NewOrderedWith(1, 3)}).(*LockedOrdered[int])
+	if !plain.AddSorted(2) || plain.AddSorted(2) || !slices.Equal(Elements[int](plain), []int{1, 2, 3}) {
+		t.Fatalf("AddSorted on an inner set without AddSorted left %v", plain)
+	}
+	unsorted := NewLockedOrderedWrapping[int](plainOrdered[int]{NewOrderedWith(3, 1)}).(*LockedOrdered[int])
+	if !unsorted.AddSorted(2) || !slices.Equal(Elements[int](unsorted), []int{3, 1, 2}) {
+		t.Fatalf("AddSorted sorted an unsorted inner set: %v", unsorted)
+	}
+}
+
+// TestLockedOrdered_WrappingBounded checks the reordering methods of a LockedOrdered wrapping a Bounded, which has no
+// AddSorted, Rotate or Swap of its own.
+func TestLockedOrdered_WrappingBounded(t *testing.T) {
+	t.Parallel()
+
+	b := NewBounded[int](3)
+	AppendSeq[int](b, slices.Values([]int{1, 3}))
+	s := NewLockedOrderedWrapping[int](b).(*LockedOrdered[int])
+	check := func(op string, want ...int) {
+		t.Helper()
+		if got := Elements[int](s); !slices.Equal(got, want) {
+			t.Fatalf("after %s: %v, want %v", op, got, want)
+		}
+	}
+	if !s.AddSorted(2) || s.AddSorted(2) {
+		t.Fatal("AddSorted(2) did not report adding it once")
+	}
+	check("AddSorted(2)", 1, 2, 3)
+	if !s.AddSorted(4) {
+		t.Fatal("AddSorted(4) = false")
+	}
+	check("AddSorted(4), evicting the oldest", 2, 3, 4)
+	s.Rotate(1)
+	check("Rotate(1)", 3, 4, 2)
+	s.Rotate(-4)
+	check("Rotate(-4)", 2, 3, 4)
+	if !s.Swap(0, -1) || s.Swap(0, 3) {
+		t.Fatal("Swap did not report the indexes' bounds")
+	}
+	check("Swap(0, -1)", 4, 3, 2)
+	if !s.MoveToFront(2) || !s.MoveToBack(4) {
+		t.Fatal("MoveToFront/MoveToBack reported a missing element")
+	}
+	check("MoveToFront(2), MoveToBack(4)", 2, 3, 4)
+	if b.Limit() != 3 || b.Cardinality() != 3 {
+		t.Fatalf("inner Bounded changed: %v", b)
+	}
+}
+
 func TestEqualOrdered(t *testing.T) {
 	t.Parallel()
 	s := NewOrdered[int]()
@@ -1018,6 +1687,31 @@ func TestOrdered_JSON(t *testing.T) {
 	}
 }
 
+// TestOrdered_UnmarshalJSONDuplicates pins that duplicates in a JSON array are dropped, keeping the order of first
+// occurrence.
+func TestOrdered_UnmarshalJSONDuplicates(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range []OrderedSet[int]{NewOrdered[int](), NewLockedOrdered[int]()} {
+		if err := json.Unmarshal([]byte("[1,2,2,3]"), s); err != nil {
+			t.Fatalf("%T: unexpected error: %v", s, err)
+		}
+		if s.Cardinality() != 3 {
+			t.Errorf("%T: Cardinality() = %d, want 3", s, s.Cardinality())
+		}
+		if got := Elements(s); !slices.Equal(got, []int{1, 2, 3}) {
+			t.Errorf("%T: elements = %v, want [1 2 3]", s, got)
+		}
+
+		if err := json.Unmarshal([]byte("[3,1,3,2,1]"), s); err != nil {
+			t.Fatalf("%T: unexpected error: %v", s, err)
+		}
+		if got := Elements(s); !slices.Equal(got, []int{3, 1, 2}) {
+			t.Errorf("%T: elements = %v, want [3 1 2]", s, got)
+		}
+	}
+}
+
 func TestLockedOrdered_JSON(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,226 @@
+package sets
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"slices"
+)
+
+// SmallSet is a set backed by a plain slice, found by linear scan, for sets that stay tiny. Below a dozen or so
+// elements a scan of a few contiguous elements beats hashing into a map, and a SmallSet costs only its slice, where a
+// Map carries the map header and buckets even when nearly empty, which adds up when a program holds many small sets.
+// Add, Remove and Contains are O(n), so the advantage reverses as the set grows: BenchmarkSmallSetCrossover puts the
+// crossover for Contains between 8 and 16 int elements on amd64, sooner for types that are costly to compare, such as
+// long strings, while building a set stays cheaper to well past 64 elements, as a slice grows more cheaply than a map.
+// Use a Map for sets that may grow past a dozen or so elements and are queried more than they are built.
+//
+// SmallSet is not an OrderedSet, but it iterates in insertion order, and Remove keeps the order of the remaining
+// elements. Pop removes the most recently added element. It is not safe for concurrent use. The set must not be
+// modified during iteration.
+//
+// SmallSet's zero value is ready to use.
+type SmallSet[M comparable] struct {
+	el []M
+}
+
+var _ Set[int] = new(SmallSet[int])
+var _ driver.Valuer = new(SmallSet[int])
+var _ capacitySet = new(SmallSet[int])
+
+// NewSmallSet returns an empty *SmallSet[M].
+func NewSmallSet[M comparable]() *SmallSet[M] {
+	return &SmallSet[M]{}
+}
+
+// NewSmallSetFrom returns a new *SmallSet[M] filled with the values from the sequence, in the order first seen.
+func NewSmallSetFrom[M comparable](seq iter.Seq[M]) *SmallSet[M] {
+	s := NewSmallSet[M]()
+	for x := range seq {
+		s.Add(x)
+	}
+	return s
+}
+
+// NewSmallSetWith returns a new *SmallSet[M] with the values provided, in the order first seen.
+func NewSmallSetWith[M comparable](m ...M) *SmallSet[M] {
+	s := &SmallSet[M]{el: make([]M, 0, len(m))}
+	for _, x := range m {
+		s.Add(x)
+	}
+	return s
+}
+
+// Contains returns true if the set contains the element.
+func (s *SmallSet[M]) Contains(m M) bool {
+	if s == nil {
+		return false
+	}
+	return slices.Contains(s.el, m)
+}
+
+// Clear the set and returns the number of elements removed. The backing array is kept for reuse.
+func (s *SmallSet[M]) Clear() int {
+	n := len(s.el)
+	clear(s.el)
+	s.el = s.el[:0]
+	return n
+}
+
+// Drain removes all elements from the set and returns them in insertion order. Returns nil if the set is empty.
+func (s *SmallSet[M]) Drain() []M {
+	if len(s.el) == 0 {
+		return nil
+	}
+	out := slices.Clone(s.el)
+	s.Clear()
+	return out
+}
+
+// Add an element to the end of the set. Returns true if the element was added, false if it was already present.
+func (s *SmallSet[M]) Add(m M) bool {
+	if slices.Contains(s.el, m) {
+		return false
+	}
+	s.el = append(s.el, m)
+	return true
+}
+
+// Merge adds all of other's elements to the set in place and returns the number that were not already present. It is
+// the in-place form of Union, avoiding the copy Union makes.
+func (s *SmallSet[M]) Merge(other Set[M]) int {
+	return AppendSeq[M](s, orEmpty(other).Iterator)
+}
+
+// RemoveAll removes all of other's elements from the set in place and returns the number removed. It is the in-place
+// form of Difference, avoiding the copy Difference makes.
+func (s *SmallSet[M]) RemoveAll(other Set[M]) int {
+	if Set[M](s) == other {
+		return s.Clear()
+	}
+	other = orEmpty(other)
+	n := len(s.el)
+	s.el = slices.DeleteFunc(s.el, other.Contains)
+	return n - len(s.el)
+}
+
+// Keep removes every element that is not in other from the set in place and returns the number removed. It is the
+// in-place form of Intersection, avoiding the copy Intersection makes.
+func (s *SmallSet[M]) Keep(other Set[M]) int {
+	if Set[M](s) == other {
+		return 0
+	}
+	other = orEmpty(other)
+	n := len(s.el)
+	s.el = slices.DeleteFunc(s.el, func(m M) bool { return !other.Contains(m) })
+	return n - len(s.el)
+}
+
+// Remove an element from the set. Returns true if the element was removed, false if it was not present.
+func (s *SmallSet[M]) Remove(m M) bool {
+	i := slices.Index(s.el, m)
+	if i < 0 {
+		return false
+	}
+	s.el = slices.Delete(s.el, i, i+1)
+	return true
+}
+
+// Cardinality returns the number of elements in the set.
+func (s *SmallSet[M]) Cardinality() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.el)
+}
+
+// Iterator yields all elements in the set in insertion order.
+func (s *SmallSet[M]) Iterator(yield func(M) bool) {
+	if s == nil {
+		return
+	}
+	for _, k := range s.el {
+		if !yield(k) {
+			return
+		}
+	}
+}
+
+// grow preallocates room for n more elements.
+//
+//lint:ignore U1000 reached via the capacitySet type assertion in the package-level grow
+func (s *SmallSet[M]) grow(n int) {
+	s.el = slices.Grow(s.el, n)
+}
+
+// Clone returns a copy of the set, in the same order. The underlying type is the same as the original set.
+func (s *SmallSet[M]) Clone() Set[M] {
+	if s == nil {
+		return NewSmallSet[M]()
+	}
+	return &SmallSet[M]{el: slices.Clone(s.el)}
+}
+
+// NewEmpty returns a new empty *SmallSet[M].
+func (s *SmallSet[M]) NewEmpty() Set[M] {
+	return NewSmallSet[M]()
+}
+
+// Pop removes and returns the most recently added element of the set. If the set is empty, it returns the zero value
+// of M and false.
+func (s *SmallSet[M]) Pop() (M, bool) {
+	var m M
+	if len(s.el) == 0 {
+		return m, false
+	}
+	m = s.el[len(s.el)-1]
+	s.el = slices.Delete(s.el, len(s.el)-1, len(s.el))
+	return m, true
+}
+
+// String representation of the set. It returns a string of the form SmallSet[T](<elements>).
+func (s *SmallSet[M]) String() string {
+	var m M
+	return fmt.Sprintf("SmallSet[%T](%v)", m, s.el)
+}
+
+// MarshalJSON marshals the set to JSON. It returns a JSON array of the elements in insertion order. If the set is
+// empty, it returns an empty JSON array.
+func (s *SmallSet[M]) MarshalJSON() ([]byte, error) {
+	if len(s.el) == 0 {
+		return []byte("[]"), nil
+	}
+	d, err := json.Marshal(s.el)
+	if err != nil {
+		return d, fmt.Errorf("marshaling small set: %w", err)
+	}
+	return d, nil
+}
+
+// UnmarshalJSON unmarshals the set from JSON. It expects a JSON array of the elements, which are added in order;
+// repeated values are dropped. A JSON null leaves the set empty. If the JSON is invalid, it returns an error and the
+// set is left unchanged.
+func (s *SmallSet[M]) UnmarshalJSON(d []byte) error {
+	var um []M
+	if err := json.Unmarshal(d, &um); err != nil {
+		return fmt.Errorf("unmarshaling small set: %w", err)
+	}
+	s.Clear()
+	for _, m := range um {
+		s.Add(m)
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface. It returns the JSON representation of the set.
+func (s *SmallSet[M]) Value() (driver.Value, error) {
+	return s.MarshalJSON()
+}
+
+// Scan implements the sql.Scanner interface. It scans the value from the database into the set. It expects a JSON
+// array of the elements in the set. If the JSON is invalid an error is returned. If the value is nil an empty set is
+// returned.
+func (s *SmallSet[M]) Scan(src any) error {
+	return scanValue[M](src, s.Clear, s.UnmarshalJSON)
+}
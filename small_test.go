@@ -0,0 +1,118 @@
+package sets
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// TestSmallSet_Model checks SmallSet against a slice of elements in insertion order across random Add/Remove/Pop/Clear
+// sequences.
+func TestSmallSet_Model(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(t *rapid.T) {
+		s := NewSmallSet[int]()
+		var model []int
+
+		steps := rapid.IntRange(1, 200).Draw(t, "Steps")
+		for range steps {
+			v := rapid.IntRange(-10, 10).Draw(t, "Value")
+			present := slices.Contains(model, v)
+			switch rapid.IntRange(0, 3).Draw(t, "Op") {
+			case 0:
+				if s.Add(v) == present {
+					t.Fatalf("Add(%d): expected added=%v", v, !present)
+				}
+				if !present {
+					model = append(model, v)
+				}
+			case 1:
+				if s.Remove(v) != present {
+					t.Fatalf("Remove(%d): expected removed=%v", v, present)
+				}
+				model = slices.DeleteFunc(model, func(m int) bool { return m == v })
+			case 2:
+				m, ok := s.Pop()
+				if ok != (len(model) > 0) || ok && m != model[len(model)-1] {
+					t.Fatalf("Pop() = %d, %v, want the last added of %v", m, ok, model)
+				}
+				if ok {
+					model = model[:len(model)-1]
+				}
+			case 3:
+				if n := s.Clear(); n != len(model) {
+					t.Fatalf("Clear() = %d, want %d", n, len(model))
+				}
+				model = nil
+			}
+
+			if got := Elements[int](s); !slices.Equal(got, model) {
+				t.Fatalf("Elements() = %v, want %v", got, model)
+			}
+		}
+	})
+}
+
+func TestSmallSet_ZeroValueAndClone(t *testing.T) {
+	t.Parallel()
+
+	var s SmallSet[int]
+	if s.Cardinality() != 0 || s.Contains(1) {
+		t.Fatal("zero value SmallSet is not empty")
+	}
+	s.Add(2)
+	s.Add(1)
+	c := s.Clone().(*SmallSet[int])
+	c.Remove(2)
+	if !s.Contains(2) || c.Contains(2) {
+		t.Fatalf("Clone shares elements: original %v, clone %v", &s, c)
+	}
+	if _, ok := s.NewEmpty().(*SmallSet[int]); !ok {
+		t.Fatalf("NewEmpty returned %T", s.NewEmpty())
+	}
+	if got, want := s.String(), "SmallSet[int]([2 1])"; got != want {
+		t.Fatalf("String() = %q, want %q", got, want)
+	}
+	if got := s.Drain(); !slices.Equal(got, []int{2, 1}) || s.Cardinality() != 0 {
+		t.Fatalf("Drain() = %v, leaving %v", got, &s)
+	}
+}
+
+func TestSmallSet_JSON(t *testing.T) {
+	t.Parallel()
+
+	s := NewSmallSetWith(3, 1, 2, 1)
+	j, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(j) != "[3,1,2]" {
+		t.Fatalf("MarshalJSON() = %s, want [3,1,2]", j)
+	}
+	var c *SmallSet[int]
+	if err := json.Unmarshal(j, &c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(Elements[int](c), []int{3, 1, 2}) {
+		t.Fatalf("round trip = %v, want [3 1 2]", c)
+	}
+
+	if err := c.UnmarshalJSON([]byte(`["a"]`)); err == nil {
+		t.Fatal("expected error unmarshaling mismatched element type")
+	}
+	if c.Cardinality() != 3 {
+		t.Fatalf("set changed after failed unmarshal: %v", c)
+	}
+	if err := c.Scan(nil); err != nil || c.Cardinality() != 0 {
+		t.Fatalf("Scan(nil) = %v, Cardinality() = %d", err, c.Cardinality())
+	}
+	if err := c.Scan(`[4,4]`); err != nil || !slices.Equal(Elements[int](c), []int{4}) {
+		t.Fatalf("Scan = %v, set %v", err, c)
+	}
+	if v, err := NewSmallSet[int]().Value(); err != nil || string(v.([]byte)) != "[]" {
+		t.Fatalf("empty Value() = %s, %v", v, err)
+	}
+}
@@ -75,6 +75,9 @@ func NewSortedSetWith[M cmp.Ordered](m ...M) *SortedSet[M] {
 
 // Contains returns true if the set contains the element.
 func (s *SortedSet[M]) Contains(m M) bool {
+	if s == nil {
+		return false
+	}
 	_, ok := slices.BinarySearch(s.el, m)
 	return ok
 }
@@ -87,6 +90,17 @@ func (s *SortedSet[M]) Clear() int {
 	return n
 }
 
+// Drain removes all elements from the set and returns them in ascending order. Returns nil if the set is empty. The
+// set hands over its backing slice rather than copying it.
+func (s *SortedSet[M]) Drain() []M {
+	if len(s.el) == 0 {
+		return nil
+	}
+	out := s.el
+	s.el = make([]M, 0)
+	return out
+}
+
 // Add an element to the set. Returns true if the element was added, false if it was already present.
 // The element is inserted at its sorted position.
 func (s *SortedSet[M]) Add(m M) bool {
@@ -98,6 +112,64 @@ func (s *SortedSet[M]) Add(m M) bool {
 	return true
 }
 
+// AddSorted is Add: a SortedSet always inserts an element at its sorted position. It lets a SortedSet stand in where
+// Ordered.AddSorted is used, e.g. inside a LockedOrdered.
+func (s *SortedSet[M]) AddSorted(m M) bool {
+	return s.Add(m)
+}
+
+// Merge adds all of other's elements to the set in place and returns the number that were not already present. Unlike
+// adding them one at a time, which is O(N) per element, it sorts other's elements (unless other is also a *SortedSet
+// or a *Frozen) and merges them in with a single O(N+M) pass.
+func (s *SortedSet[M]) Merge(other Set[M]) int {
+	var add []M
+	if o, ok := sortedOperand(other); ok && o != nil {
+		add = o.el
+	} else {
+		add = SortedSlice(orEmpty(other))
+	}
+	if len(add) == 0 {
+		return 0
+	}
+	n := len(s.el)
+	s.el = mergeSorted(s.el, add, true, true, true)
+	return len(s.el) - n
+}
+
+// RemoveAll removes all of other's elements from the set in place and returns the number removed. Like Merge, it sorts
+// other's elements (unless other is also a *SortedSet or a *Frozen) and removes them with a single O(N+M) pass.
+func (s *SortedSet[M]) RemoveAll(other Set[M]) int {
+	var del []M
+	if o, ok := sortedOperand(other); ok && o != nil {
+		del = o.el
+	} else {
+		del = SortedSlice(orEmpty(other))
+	}
+	if len(del) == 0 || len(s.el) == 0 {
+		return 0
+	}
+	n := len(s.el)
+	s.el = mergeSorted(s.el, del, true, false, false)
+	return n - len(s.el)
+}
+
+// Keep removes every element that is not in other from the set in place and returns the number removed. When other is
+// also a *SortedSet or a *Frozen the two are intersected with a single O(N+M) merge; otherwise each element is looked
+// up in other and the misses are compacted out in one pass.
+func (s *SortedSet[M]) Keep(other Set[M]) int {
+	n := len(s.el)
+	if o, ok := sortedOperand(other); ok && o != nil {
+		if o == s {
+			return 0
+		}
+		s.el = mergeSorted(s.el, o.el, false, false, true)
+	} else {
+		other = orEmpty(other)
+		s.el = slices.DeleteFunc(s.el, func(m M) bool { return !other.Contains(m) })
+	}
+	return n - len(s.el)
+}
+
 // Remove an element from the set. Returns true if the element was removed, false if it was not present.
 func (s *SortedSet[M]) Remove(m M) bool {
 	i, ok := slices.BinarySearch(s.el, m)
@@ -118,6 +190,9 @@ func (s *SortedSet[M]) Cardinality() int {
 
 // Iterator yields all elements in the set in ascending order.
 func (s *SortedSet[M]) Iterator(yield func(M) bool) {
+	if s == nil {
+		return
+	}
 	for _, v := range s.el {
 		if !yield(v) {
 			return
@@ -127,6 +202,9 @@ func (s *SortedSet[M]) Iterator(yield func(M) bool) {
 
 // Clone returns a copy of the set. The underlying type is the same as the original set.
 func (s *SortedSet[M]) Clone() Set[M] {
+	if s == nil {
+		return NewSortedSet[M]()
+	}
 	return &SortedSet[M]{el: slices.Clone(s.el)}
 }
 
@@ -275,71 +353,71 @@ func (s *SortedSet[M]) Min() (M, bool) {
 	return s.el[0], true
 }
 
-// Union implements Unioner: when other is also a *SortedSet[M] it returns the union computed by a
+// Union implements Unioner: when other is also a *SortedSet[M] (or a *Frozen[M]) it returns the union computed by a
 // single O(N+M) linear merge of the two sorted slices and true; otherwise it returns nil and
 // false. Prefer the package-level Union function, which uses this automatically and handles the
 // fallback.
 func (s *SortedSet[M]) Union(other Set[M]) (Set[M], bool) {
-	o, ok := other.(*SortedSet[M])
-	if !ok {
+	o, ok := sortedOperand(other)
+	if !ok || s == nil || o == nil { // typed-nil operands decline to the generic path
 		return nil, false
 	}
 	return &SortedSet[M]{el: mergeSorted(s.el, o.el, true, true, true)}, true
 }
 
-// Intersection implements Intersectioner: when other is also a *SortedSet[M] it returns the
+// Intersection implements Intersectioner: when other is also a *SortedSet[M] (or a *Frozen[M]) it returns the
 // intersection computed by a single O(N+M) linear merge of the two sorted slices and true;
 // otherwise it returns nil and false. Prefer the package-level Intersection function, which uses
 // this automatically and handles the fallback.
 func (s *SortedSet[M]) Intersection(other Set[M]) (Set[M], bool) {
-	o, ok := other.(*SortedSet[M])
-	if !ok {
+	o, ok := sortedOperand(other)
+	if !ok || s == nil || o == nil { // typed-nil operands decline to the generic path
 		return nil, false
 	}
 	return &SortedSet[M]{el: mergeSorted(s.el, o.el, false, false, true)}, true
 }
 
-// Difference implements Differencer: when other is also a *SortedSet[M] it returns the difference
+// Difference implements Differencer: when other is also a *SortedSet[M] (or a *Frozen[M]) it returns the difference
 // computed by a single O(N+M) linear merge of the two sorted slices and true; otherwise it returns
 // nil and false. Prefer the package-level Difference function, which uses this automatically and
 // handles the fallback.
 func (s *SortedSet[M]) Difference(other Set[M]) (Set[M], bool) {
-	o, ok := other.(*SortedSet[M])
-	if !ok {
+	o, ok := sortedOperand(other)
+	if !ok || s == nil || o == nil { // typed-nil operands decline to the generic path
 		return nil, false
 	}
 	return &SortedSet[M]{el: mergeSorted(s.el, o.el, true, false, false)}, true
 }
 
-// SymmetricDifference implements SymmetricDifferencer: when other is also a *SortedSet[M] it
+// SymmetricDifference implements SymmetricDifferencer: when other is also a *SortedSet[M] (or a *Frozen[M]) it
 // returns the symmetric difference computed by a single O(N+M) linear merge of the two sorted
 // slices and true; otherwise it returns nil and false. Prefer the package-level
 // SymmetricDifference function, which uses this automatically and handles the fallback.
 func (s *SortedSet[M]) SymmetricDifference(other Set[M]) (Set[M], bool) {
-	o, ok := other.(*SortedSet[M])
-	if !ok {
+	o, ok := sortedOperand(other)
+	if !ok || s == nil || o == nil { // typed-nil operands decline to the generic path
 		return nil, false
 	}
 	return &SortedSet[M]{el: mergeSorted(s.el, o.el, true, true, false)}, true
 }
 
-// Equal implements Equaler: when other is also a *SortedSet[M] it compares the two sorted backing
+// Equal implements Equaler: when other is also a *SortedSet[M] (or a *Frozen[M]) it compares the two sorted backing
 // slices element-wise and reports handled; otherwise it reports false, false. Prefer the
 // package-level Equal function, which uses this automatically and handles the fallback.
 func (s *SortedSet[M]) Equal(other Set[M]) (bool, bool) {
-	o, ok := other.(*SortedSet[M])
+	o, ok := sortedOperand(other)
 	if !ok || s == nil || o == nil { // typed-nil operands decline to the generic path
 		return false, false
 	}
 	return slices.Equal(s.el, o.el), true
 }
 
-// Disjoint implements Disjointer: when other is also a *SortedSet[M] it reports whether the two
+// Disjoint implements Disjointer: when other is also a *SortedSet[M] (or a *Frozen[M]) it reports whether the two
 // sorted backing slices share an element, found by a short-circuiting two-pointer scan, and
 // handled; otherwise it reports false, false. Prefer the package-level Disjoint function, which
 // uses this automatically and handles the fallback.
 func (s *SortedSet[M]) Disjoint(other Set[M]) (bool, bool) {
-	o, ok := other.(*SortedSet[M])
+	o, ok := sortedOperand(other)
 	if !ok || s == nil || o == nil { // typed-nil operands decline to the generic path
 		return false, false
 	}
@@ -357,12 +435,12 @@ func (s *SortedSet[M]) Disjoint(other Set[M]) (bool, bool) {
 	return true, true
 }
 
-// Subset implements Subsetter: when other is also a *SortedSet[M] it reports whether every
+// Subset implements Subsetter: when other is also a *SortedSet[M] (or a *Frozen[M]) it reports whether every
 // element of the receiver appears in other, found by a short-circuiting two-pointer scan, and
 // handled; otherwise it reports false, false. Prefer the package-level Subset function, which
 // uses this automatically and handles the fallback.
 func (s *SortedSet[M]) Subset(other Set[M]) (bool, bool) {
-	o, ok := other.(*SortedSet[M])
+	o, ok := sortedOperand(other)
 	if !ok || s == nil || o == nil { // typed-nil operands decline to the generic path
 		return false, false
 	}
@@ -382,6 +460,18 @@ func (s *SortedSet[M]) Subset(other Set[M]) (bool, bool) {
 	return true, true
 }
 
+// sortedOperand returns the sorted backing of other when it is a *SortedSet[M] or a *Frozen[M], so the merge-based
+// optimizations apply to either.
+func sortedOperand[M cmp.Ordered](other Set[M]) (*SortedSet[M], bool) {
+	switch o := other.(type) {
+	case *SortedSet[M]:
+		return o, true
+	case *Frozen[M]:
+		return o.sorted(), true
+	}
+	return nil, false
+}
+
 // mergeSorted linearly merges two ascending, duplicate-free slices into a new ascending,
 // duplicate-free slice, keeping the elements found only in a, only in b, or in both, according to
 // the flags. Each set-algebra operation is a flag combination: union keeps everything (true, true,
@@ -114,9 +114,12 @@ func check(t *testing.T, s *sets.Ordered[int], r *model, trial, op int) {
 		if idx := s.Index(v); idx != i {
 			t.Fatalf("trial %d op %d: Index(%d) = %d, want %d", trial, op, v, idx, i)
 		}
+		if got, ok := s.At(i - len(want)); !ok || got != v {
+			t.Fatalf("trial %d op %d: At(%d) = %d, %t, want %d, true", trial, op, i-len(want), got, ok, v)
+		}
 	}
-	if _, ok := s.At(-1); ok {
-		t.Fatalf("trial %d op %d: At(-1) ok = true", trial, op)
+	if _, ok := s.At(-len(want) - 1); ok {
+		t.Fatalf("trial %d op %d: At(%d) ok = true", trial, op, -len(want)-1)
 	}
 	if _, ok := s.At(len(want)); ok {
 		t.Fatalf("trial %d op %d: At(%d) ok = true", trial, op, len(want))
@@ -184,6 +187,112 @@ func TestSyncMapConcurrentClearCount(t *testing.T) {
 	}
 }
 
+// TestSyncMapSnapshotConcurrent takes snapshots of a SyncMap while other goroutines add and remove elements. Every
+// snapshot must hold each stable element exactly once and none of the elements removed before the snapshots started.
+func TestSyncMapSnapshotConcurrent(t *testing.T) {
+	t.Parallel()
+
+	const stable, churn = 100, 100
+	s := sets.NewSyncMap[int]()
+	for i := range stable + churn {
+		s.Add(i)
+	}
+	for i := stable; i < stable+churn; i++ {
+		s.Remove(i) // removed before any snapshot starts
+	}
+
+	stop := make(chan struct{})
+	var writers sync.WaitGroup
+	for w := range 4 {
+		writers.Go(func() {
+			for i := 0; ; i++ {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				v := stable + churn + w*churn + i%churn
+				s.Add(v)
+				s.Remove(v)
+			}
+		})
+	}
+
+	for range 500 {
+		seen := make(map[int]int)
+		for _, v := range s.Snapshot() {
+			seen[v]++
+		}
+		for v, n := range seen {
+			if n != 1 {
+				t.Fatalf("snapshot holds %d %d times", v, n)
+			}
+			if v >= stable && v < stable+churn {
+				t.Fatalf("snapshot holds %d, which was removed before it started", v)
+			}
+		}
+		for v := range stable {
+			if seen[v] != 1 {
+				t.Fatalf("snapshot is missing stable element %d", v)
+			}
+		}
+	}
+	close(stop)
+	writers.Wait()
+}
+
+// TestSyncMapConcurrentPredicates runs Equal, Subset, Disjoint, and the set-algebra functions on SyncMaps while
+// other goroutines modify them. Under concurrent writes the results need not match any single moment (see the SyncMap
+// documentation), so the test only requires that nothing panics or races, and that the answers are exact again once
+// the writers stop.
+func TestSyncMapConcurrentPredicates(t *testing.T) {
+	t.Parallel()
+
+	const n = 200
+	a, b := sets.NewSyncMap[int](), sets.NewSyncMap[int]()
+	for i := range n {
+		a.Add(i)
+		b.Add(i)
+	}
+
+	stop := make(chan struct{})
+	var writers sync.WaitGroup
+	for _, s := range []*sets.SyncMap[int]{a, b} {
+		writers.Go(func() {
+			for i := 0; ; i++ {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				v := n + i%n // never touches the shared 0..n-1 elements
+				s.Add(v)
+				s.Remove(v)
+			}
+		})
+	}
+
+	var readers sync.WaitGroup
+	for range 4 {
+		readers.Go(func() {
+			for range 200 {
+				sets.Equal[int](a, b)
+				sets.Subset[int](a, b)
+				sets.Disjoint[int](a, b)
+				sets.Union[int](a, b)
+				sets.SymmetricDifference[int](a, b)
+			}
+		})
+	}
+	readers.Wait()
+	close(stop)
+	writers.Wait()
+
+	if !sets.Equal[int](a, b) || !sets.Subset[int](a, b) || sets.Disjoint[int](a, b) {
+		t.Fatal("predicates on quiescent, equal SyncMaps gave the wrong answer")
+	}
+}
+
 // TestLockedWrappingPreservesOrder verifies that a Locked set wrapping an
 // ordered set keeps the wrapped set's insertion-order semantics through Clone
 // and NewEmpty.
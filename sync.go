@@ -10,6 +10,15 @@ import (
 )
 
 // SyncMap is a concurrency safe set type that uses a sync.Map.
+//
+// Each method is safe to call concurrently, but no method or package-level function takes a consistent snapshot of the
+// whole set: like sync.Map's Range, iteration (including Snapshot) may or may not reflect writes made while it runs,
+// and Cardinality counts by iterating. Functions that read a set more than once, such as Equal (which compares
+// cardinalities and then probes each element) or Subset, therefore see a consistent view only while no writes are in
+// progress. Under concurrent writes they never panic, but their result may not match the sets' contents at any single
+// moment, e.g. Equal can report two sets that were always equal as unequal. Copying the elements first does not help,
+// since the copy is taken by iterating too. When comparing live sets must give an exact answer, use Locked, whose
+// methods and optimized comparisons run under its lock.
 type SyncMap[M comparable] struct {
 	m sync.Map
 }
@@ -44,6 +53,9 @@ func NewSyncMapWith[M comparable](m ...M) *SyncMap[M] {
 }
 
 func (s *SyncMap[M]) Contains(m M) bool {
+	if s == nil {
+		return false
+	}
 	_, ok := s.m.Load(m)
 	return ok
 }
@@ -60,11 +72,51 @@ func (s *SyncMap[M]) Clear() int {
 	return n
 }
 
+// Drain removes all elements from the set and returns them. Returns nil if the set is empty. Like Clear, it removes
+// elements one at a time, so an element added concurrently may or may not be drained, but each element removed is
+// returned exactly once.
+func (s *SyncMap[M]) Drain() []M {
+	var out []M
+	s.m.Range(func(k, _ any) bool {
+		if _, loaded := s.m.LoadAndDelete(k); loaded {
+			out = append(out, k.(M))
+		}
+		return true
+	})
+	return out
+}
+
 func (s *SyncMap[M]) Add(m M) bool {
 	_, loaded := s.m.LoadOrStore(m, struct{}{})
 	return !loaded
 }
 
+// Merge adds all of other's elements to the set in place and returns the number that were not already present. Each
+// element is added individually, so concurrent readers may observe a partial merge.
+func (s *SyncMap[M]) Merge(other Set[M]) int {
+	return AppendSeq[M](s, orEmpty(other).Iterator)
+}
+
+// RemoveAll removes all of other's elements from the set in place and returns the number removed. It is the in-place
+// form of Difference, avoiding the copy Difference makes. Each element is removed individually, so concurrent readers
+// may observe a partial removal.
+func (s *SyncMap[M]) RemoveAll(other Set[M]) int {
+	if Set[M](s) == other {
+		return s.Clear()
+	}
+	return RemoveSeq[M](s, orEmpty(other).Iterator)
+}
+
+// Keep removes every element that is not in other from the set in place and returns the number removed. It is the
+// in-place form of Intersection, avoiding the copy Intersection makes. Like RemoveAll, it removes elements
+// individually, so concurrent readers may observe a partial removal.
+func (s *SyncMap[M]) Keep(other Set[M]) int {
+	if Set[M](s) == other {
+		return 0
+	}
+	return keepOnly[M](s, other)
+}
+
 func (s *SyncMap[M]) Pop() (M, bool) {
 	var m M
 	var ok bool
@@ -99,12 +151,35 @@ func (s *SyncMap[M]) Cardinality() int {
 // Iterator yields all elements in the set. It is safe to call concurrently with other methods, but the order and
 // behavior is undefined, as per [sync.Map]'s `Range`.
 func (s *SyncMap[M]) Iterator(yield func(M) bool) {
+	if s == nil {
+		return
+	}
 	s.m.Range(func(key, _ any) bool {
 		return yield(key.(M))
 	})
 }
 
+// Snapshot returns the elements of the set, in no particular order, collected in a single pass over the underlying
+// sync.Map. Returns nil if the set is empty. Sort the result for reproducible output. Elements present for the whole
+// call are always included and elements removed before it started never are; an element added or removed while it
+// runs may or may not be. Unlike Elements, which counts the set and then iterates it, the result comes from one
+// iteration, so it never mixes two views of the set.
+func (s *SyncMap[M]) Snapshot() []M {
+	if s == nil {
+		return nil
+	}
+	var out []M
+	s.m.Range(func(key, _ any) bool {
+		out = append(out, key.(M))
+		return true
+	})
+	return out
+}
+
 func (s *SyncMap[M]) Clone() Set[M] {
+	if s == nil {
+		return NewSyncMap[M]()
+	}
 	return NewSyncMapFrom(s.Iterator)
 }
 
@@ -117,6 +192,10 @@ func (s *SyncMap[M]) String() string {
 	return fmt.Sprintf("SyncSet[%T](%v)", m, slices.Collect(s.Iterator))
 }
 
+// MarshalJSON implements json.Marshaler. It will marshal the set into a JSON array of the elements in the set. If the
+// set is empty an empty JSON array is returned. The elements are emitted in iteration order, which is arbitrary, so the
+// same set may marshal differently from one call to the next; sorting would cost O(N log N) on every call, so it is
+// not done by default. Use MarshalJSONSorted when the output must be reproducible, e.g. as a cache key.
 func (s *SyncMap[M]) MarshalJSON() ([]byte, error) {
 	v := slices.Collect(s.Iterator)
 	if len(v) == 0 {
@@ -0,0 +1,156 @@
+package sets
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"time"
+)
+
+// TimeSet is a set of time.Time values that dedupes them by instant, at a chosen resolution. time.Time is comparable,
+// but == compares more than the instant: a time read from the clock carries a monotonic clock reading, and each time
+// carries its location, so two times for the same instant, e.g. one parsed and one from time.Now, are often not == and
+// are not deduped by a Map[time.Time]. TimeSet normalizes every time it is given, in Add, Contains and Remove alike:
+// it strips the monotonic reading, converts to UTC, and truncates to the set's resolution (see time.Time.Truncate), so
+// e.g. with a resolution of time.Second all times within the same second are the same element. The elements are held
+// normalized, so the set yields UTC times truncated to its resolution, not the times as they were added. It is not
+// ordered and does not guarantee the order of elements when iterating over them. It is not safe for concurrent use.
+//
+// TimeSet's zero value is ready to use, with no truncation.
+type TimeSet struct {
+	set      Map[time.Time]
+	truncate time.Duration
+}
+
+var _ Set[time.Time] = new(TimeSet)
+var _ driver.Valuer = new(TimeSet)
+
+// NewTimeSet returns an empty *TimeSet that truncates times to multiples of truncate since the zero time. A truncate of
+// 0 or less keeps full precision, still stripping monotonic readings and locations.
+func NewTimeSet(truncate time.Duration) *TimeSet {
+	return &TimeSet{set: Map[time.Time]{set: make(map[time.Time]struct{})}, truncate: truncate}
+}
+
+// NewTimeSetFrom returns a new *TimeSet, truncating to truncate, filled with the times from the sequence.
+func NewTimeSetFrom(truncate time.Duration, seq iter.Seq[time.Time]) *TimeSet {
+	s := NewTimeSet(truncate)
+	AppendSeq[time.Time](s, seq)
+	return s
+}
+
+// Truncation returns the resolution the set truncates times to; 0 or less means none.
+func (s *TimeSet) Truncation() time.Duration {
+	if s == nil {
+		return 0
+	}
+	return s.truncate
+}
+
+// normalize returns t as the set holds it: in UTC, without a monotonic reading, and truncated to the set's resolution.
+func (s *TimeSet) normalize(t time.Time) time.Time {
+	return t.Round(0).UTC().Truncate(s.Truncation())
+}
+
+// Add the time, normalized, to the set. Returns true if it was added, false if the set already held the same
+// normalized time.
+func (s *TimeSet) Add(t time.Time) bool {
+	if s.set.set == nil {
+		s.set.set = make(map[time.Time]struct{})
+	}
+	return s.set.Add(s.normalize(t))
+}
+
+// Merge adds all of other's times to the set in place, normalized, and returns the number that were not already
+// present. It is the in-place form of Union, avoiding the copy Union makes.
+func (s *TimeSet) Merge(other Set[time.Time]) int {
+	return AppendSeq[time.Time](s, orEmpty(other).Iterator)
+}
+
+// Remove the time, normalized, from the set. Returns true if the set held the same normalized time.
+func (s *TimeSet) Remove(t time.Time) bool {
+	return s.set.Remove(s.normalize(t))
+}
+
+// Contains returns true if the set holds the time, once normalized.
+func (s *TimeSet) Contains(t time.Time) bool {
+	if s == nil {
+		return false
+	}
+	return s.set.Contains(s.normalize(t))
+}
+
+// Cardinality returns the number of elements in the set.
+func (s *TimeSet) Cardinality() int {
+	if s == nil {
+		return 0
+	}
+	return s.set.Cardinality()
+}
+
+// Clear the set and returns the number of elements removed.
+func (s *TimeSet) Clear() int {
+	return s.set.Clear()
+}
+
+// Iterator yields all elements in the set, normalized. The set must not be modified during iteration.
+func (s *TimeSet) Iterator(yield func(time.Time) bool) {
+	if s == nil {
+		return
+	}
+	s.set.Iterator(yield)
+}
+
+// Clone returns a copy of the set, with the same resolution.
+func (s *TimeSet) Clone() Set[time.Time] {
+	if s == nil {
+		return NewTimeSet(0)
+	}
+	return &TimeSet{set: *s.set.Clone().(*Map[time.Time]), truncate: s.truncate}
+}
+
+// NewEmpty returns a new empty *TimeSet with the same resolution.
+func (s *TimeSet) NewEmpty() Set[time.Time] {
+	return NewTimeSet(s.Truncation())
+}
+
+// Pop removes and returns an element from the set. If the set is empty, it returns the zero time and false.
+func (s *TimeSet) Pop() (time.Time, bool) {
+	return s.set.Pop()
+}
+
+// String representation of the set. It returns a string of the form TimeSet[<resolution>](<elements>).
+func (s *TimeSet) String() string {
+	return fmt.Sprintf("TimeSet[%v](%v)", s.Truncation(), Elements[time.Time](s))
+}
+
+// MarshalJSON implements json.Marshaler. It returns a JSON array of the elements in the set, as RFC 3339 strings. If
+// the set is empty, it returns an empty JSON array.
+func (s *TimeSet) MarshalJSON() ([]byte, error) {
+	return s.set.MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It expects a JSON array of RFC 3339 times, which are normalized as by Add.
+// A JSON null leaves the set empty. If the JSON is invalid, it returns an error and the set is left unchanged.
+func (s *TimeSet) UnmarshalJSON(d []byte) error {
+	var um []time.Time
+	if err := json.Unmarshal(d, &um); err != nil {
+		return fmt.Errorf("unmarshaling time set: %w", err)
+	}
+	s.Clear()
+	for _, t := range um {
+		s.Add(t)
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface. It returns the JSON representation of the set.
+func (s *TimeSet) Value() (driver.Value, error) {
+	return s.MarshalJSON()
+}
+
+// Scan implements the sql.Scanner interface. It scans the value from the database into the set. It expects a JSON
+// array of RFC 3339 times. If the JSON is invalid an error is returned. If the value is nil an empty set is returned.
+func (s *TimeSet) Scan(src any) error {
+	return scanValue[time.Time](src, s.Clear, s.UnmarshalJSON)
+}
@@ -0,0 +1,73 @@
+package sets
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimeSet(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now() // carries a monotonic reading
+	s := NewTimeSet(time.Second)
+	if !s.Add(now) {
+		t.Fatal("Add(now) = false on an empty set")
+	}
+	if s.Add(now.Round(0)) {
+		t.Fatal("Add of now without its monotonic reading added a second element")
+	}
+	if s.Add(now.In(time.FixedZone("X", 3600))) {
+		t.Fatal("Add of now in another location added a second element")
+	}
+	if s.Add(now.Truncate(time.Second).Add(999 * time.Millisecond)) {
+		t.Fatal("Add of a time in the same second added a second element")
+	}
+	if !s.Contains(now.Truncate(time.Second)) || s.Contains(now.Truncate(time.Second).Add(time.Second)) {
+		t.Fatalf("Contains does not truncate to the second: %v", s)
+	}
+	for e := range s.Iterator {
+		if want := now.Round(0).UTC().Truncate(time.Second); e != want {
+			t.Fatalf("element %v, want the normalized %v", e, want)
+		}
+	}
+
+	c := s.Clone().(*TimeSet)
+	if !s.Remove(now.Add(time.Millisecond)) || s.Cardinality() != 0 {
+		t.Fatalf("Remove did not normalize: %v", s)
+	}
+	if c.Cardinality() != 1 || c.Truncation() != time.Second || s.NewEmpty().(*TimeSet).Truncation() != time.Second {
+		t.Fatalf("Clone/NewEmpty did not keep the resolution: %v", c)
+	}
+
+	var z TimeSet // zero value: no truncation, still stripping monotonic readings and locations
+	z.Add(now)
+	if z.Add(now.Round(0).In(time.FixedZone("X", 3600))) || !z.Add(now.Add(time.Nanosecond)) {
+		t.Fatalf("zero value TimeSet did not dedupe by instant at full precision: %v", &z)
+	}
+}
+
+func TestTimeSet_JSON(t *testing.T) {
+	t.Parallel()
+
+	s := NewTimeSetFrom(time.Hour, func(yield func(time.Time) bool) {
+		yield(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	})
+	d, err := json.Marshal(s)
+	if err != nil || string(d) != `["2024-01-02T03:00:00Z"]` {
+		t.Fatalf("MarshalJSON() = %s, %v", d, err)
+	}
+	c := NewTimeSet(time.Hour)
+	if err := c.UnmarshalJSON([]byte(`["2024-01-02T03:59:00Z","2024-01-02T04:30:00+01:00"]`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !Equal[time.Time](c, s) {
+		t.Fatalf("UnmarshalJSON = %v, want %v", c, s)
+	}
+	if err := c.Scan(`[1]`); err == nil || c.Cardinality() != 1 {
+		t.Fatalf("Scan([1]) = %v, left %v", err, c)
+	}
+	if err := c.Scan(nil); err != nil || c.Cardinality() != 0 {
+		t.Fatalf("Scan(nil) = %v, left %v", err, c)
+	}
+}